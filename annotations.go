@@ -0,0 +1,164 @@
+package diffy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// GitHubActionsFormatter emits workflow commands that GitHub renders as annotations on the pull request diff.
+type GitHubActionsFormatter struct{}
+
+func (f *GitHubActionsFormatter) Format(w io.Writer, report *Report) error {
+	for _, finding := range report.Findings {
+		if _, err := fmt.Fprintln(w, formatAnnotation(finding)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatAnnotation(finding ValidationFinding) string {
+	command := "warning"
+	switch finding.Level() {
+	case SeverityError:
+		command = "error"
+	case SeverityNote:
+		command = "notice"
+	}
+
+	var properties []string
+	if finding.Range.Filename != "" {
+		properties = append(properties,
+			"file="+escapeAnnotationProperty(displayPath(finding.Range.Filename)),
+			fmt.Sprintf("line=%d", finding.Range.Start.Line),
+			fmt.Sprintf("endLine=%d", finding.Range.End.Line),
+		)
+	}
+	properties = append(properties, "title="+escapeAnnotationProperty("diffy: "+finding.RuleID()))
+
+	return fmt.Sprintf("::%s %s::%s", command, strings.Join(properties, ","), escapeAnnotationData(FormatFinding(finding)))
+}
+
+func escapeAnnotationData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+func escapeAnnotationProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
+
+// WriteStepSummary renders the findings as Markdown tables grouped by module.
+func WriteStepSummary(w io.Writer, report *Report) error {
+	var b strings.Builder
+
+	b.WriteString("## diffy schema validation\n\n")
+	if len(report.Findings) == 0 {
+		b.WriteString("No validation findings.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintf(&b, "Found %d issues.\n\n", len(report.Findings))
+
+	for _, group := range groupFindingsByModule(report) {
+		fmt.Fprintf(&b, "### %s\n\n", group.name)
+		b.WriteString("| Resource | Attribute | Finding | Severity | Location |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+		for _, finding := range group.findings {
+			target := finding.Name
+			if cleanPath := strings.TrimPrefix(strings.TrimPrefix(finding.Path, "root"), "."); cleanPath != "" {
+				target = cleanPath + "." + finding.Name
+			}
+
+			fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s | %s |\n",
+				findingSubject(finding), target, findingSummary(finding), finding.Level(), markdownLocation(finding))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type moduleFindings struct {
+	name     string
+	findings []ValidationFinding
+}
+
+func groupFindingsByModule(report *Report) []moduleFindings {
+	var groups []moduleFindings
+	index := make(map[string]int)
+
+	add := func(submodule string) {
+		if _, ok := index[submodule]; ok {
+			return
+		}
+		name := submodule
+		if name == "" {
+			name = "root"
+		}
+		index[submodule] = len(groups)
+		groups = append(groups, moduleFindings{name: name})
+	}
+
+	for _, module := range report.Modules {
+		add(module.Name)
+	}
+
+	for _, finding := range report.Findings {
+		add(finding.SubmoduleName)
+		i := index[finding.SubmoduleName]
+		groups[i].findings = append(groups[i].findings, finding)
+	}
+
+	result := groups[:0]
+	for _, group := range groups {
+		if len(group.findings) > 0 {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+func markdownLocation(finding ValidationFinding) string {
+	location := FormatLocation(finding.Range)
+	if location == "" {
+		return ""
+	}
+	return "`" + location + "`"
+}
+
+func writeGitHubActionsOutput(opts *SchemaValidatorOptions, report *Report) error {
+	if shouldAnnotate(opts) {
+		if err := (&GitHubActionsFormatter{}).Format(opts.Output, report); err != nil {
+			return fmt.Errorf("failed to write GitHub annotations: %w", err)
+		}
+	}
+
+	summaryPath := os.Getenv("GITHUB_STEP_SUMMARY")
+	if summaryPath == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open step summary %s: %w", summaryPath, err)
+	}
+	defer f.Close()
+
+	if err := WriteStepSummary(f, report); err != nil {
+		return fmt.Errorf("failed to write step summary: %w", err)
+	}
+	return f.Close()
+}
+
+// shouldAnnotate avoids mixing workflow commands into machine-readable output on stdout.
+func shouldAnnotate(opts *SchemaValidatorOptions) bool {
+	if opts.Silent || strings.EqualFold(opts.Format, FormatGitHub) {
+		return false
+	}
+	return opts.OutputFile != "" || opts.Format == "" || strings.EqualFold(opts.Format, FormatText)
+}
@@ -0,0 +1,168 @@
+package diffy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestGitHubActionsFormatter(t *testing.T) {
+	report := &Report{Findings: []ValidationFinding{
+		{
+			ResourceType: "azurerm_key_vault",
+			Address:      "azurerm_key_vault.kv",
+			Path:         "root",
+			Name:         "tenant_id",
+			Required:     true,
+			Range: hcl.Range{
+				Filename: "modules/vault/main.tf",
+				Start:    hcl.Pos{Line: 4, Column: 1},
+				End:      hcl.Pos{Line: 12, Column: 2},
+			},
+		},
+		{ResourceType: "azurerm_key_vault", Address: "azurerm_key_vault.kv", Path: "root.network_acls", Name: "ip_rules"},
+	}}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatGitHub, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one command per finding, got %q", out.String())
+	}
+
+	wantFirst := "::error file=modules/vault/main.tf,line=4,endLine=12,title=diffy%3A missing-required-attribute::azurerm_key_vault.kv: missing required property tenant_id"
+	if !strings.HasPrefix(lines[0], wantFirst) {
+		t.Errorf("first annotation = %q, want prefix %q", lines[0], wantFirst)
+	}
+	if !strings.HasPrefix(lines[1], "::warning title=diffy%3A missing-optional-attribute::") {
+		t.Errorf("finding without a range should be a file-less warning, got %q", lines[1])
+	}
+}
+
+func TestEscapeAnnotation(t *testing.T) {
+	if got := escapeAnnotationData("50%\nnext"); got != "50%25%0Anext" {
+		t.Errorf("escapeAnnotationData() = %q", got)
+	}
+	if got := escapeAnnotationProperty("a:b,c"); got != "a%3Ab%2Cc" {
+		t.Errorf("escapeAnnotationProperty() = %q", got)
+	}
+}
+
+func TestWriteStepSummary(t *testing.T) {
+	report := &Report{
+		Modules: []ModuleReport{{}, {Name: "network"}, {Name: "storage"}},
+		Findings: []ValidationFinding{
+			{ResourceType: "azurerm_subnet", Address: "module.network.azurerm_subnet.this", Path: "root.delegation", Name: "service_delegation", IsBlock: true, SubmoduleName: "network"},
+			{ResourceType: "azurerm_resource_group", Address: "azurerm_resource_group.rg", Path: "root", Name: "location", Required: true},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteStepSummary(&out, report); err != nil {
+		t.Fatalf("WriteStepSummary returned error: %v", err)
+	}
+
+	got := out.String()
+	rootIdx := strings.Index(got, "### root")
+	networkIdx := strings.Index(got, "### network")
+	if rootIdx < 0 || networkIdx < rootIdx {
+		t.Fatalf("summary should group findings by module in module order:\n%s", got)
+	}
+	if strings.Contains(got, "### storage") {
+		t.Errorf("modules without findings should be omitted:\n%s", got)
+	}
+	for _, want := range []string{
+		"| `azurerm_resource_group.rg` | `location` | missing required property | error |",
+		"| `module.network.azurerm_subnet.this` | `delegation.service_delegation` | missing optional block | warning |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary should contain %q:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	if err := WriteStepSummary(&out, &Report{}); err != nil {
+		t.Fatalf("WriteStepSummary returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No validation findings.") {
+		t.Errorf("empty summary should say so, got %q", out.String())
+	}
+}
+
+func TestValidateSchemaInGitHubActions(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.tf"), "# test")
+	summary := filepath.Join(t.TempDir(), "summary.md")
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+
+	parser := &validateStubParser{
+		providerSource: "registry.terraform.io/hashicorp/azurerm",
+		resources:      []ParsedResource{{Type: "azurerm_resource_group", Name: "rg", Data: NewBlockData()}},
+	}
+	runner := &validateStubRunner{
+		schema: &TerraformSchema{
+			ProviderSchemas: map[string]*ProviderSchema{
+				parser.providerSource: {
+					ResourceSchemas: map[string]*ResourceSchema{
+						"azurerm_resource_group": {Block: &SchemaBlock{
+							Attributes: map[string]*SchemaAttribute{"location": {Required: true}},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	var stdout bytes.Buffer
+	if _, err := ValidateSchema(
+		WithTerraformRoot(root),
+		WithParser(parser),
+		WithTerraformRunner(runner),
+		WithOutput(&stdout),
+	); err != nil {
+		t.Fatalf("ValidateSchema returned error: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "::error title=diffy%3A missing-required-attribute::azurerm_resource_group.rg") {
+		t.Errorf("stdout should contain an error annotation, got %q", stdout.String())
+	}
+
+	content, err := os.ReadFile(summary)
+	if err != nil {
+		t.Fatalf("step summary was not written: %v", err)
+	}
+	if !strings.Contains(string(content), "`azurerm_resource_group.rg`") {
+		t.Errorf("step summary should list the finding, got %q", string(content))
+	}
+}
+
+func TestShouldAnnotate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SchemaValidatorOptions
+		want bool
+	}{
+		{name: "text to stdout", opts: SchemaValidatorOptions{Format: FormatText}, want: true},
+		{name: "silent", opts: SchemaValidatorOptions{Format: FormatText, Silent: true}, want: false},
+		{name: "json to stdout", opts: SchemaValidatorOptions{Format: FormatJSON}, want: false},
+		{name: "json to file", opts: SchemaValidatorOptions{Format: FormatJSON, OutputFile: "report.json"}, want: true},
+		{name: "github format", opts: SchemaValidatorOptions{Format: FormatGitHub}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldAnnotate(&tt.opts); got != tt.want {
+				t.Errorf("shouldAnnotate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package diffy
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	BaselineVersion     = "1"
+	DefaultBaselineFile = ".diffy-baseline.json"
+)
+
+// Baseline records accepted findings so later runs only report what is new.
+type Baseline struct {
+	Version string          `json:"version"`
+	Entries []BaselineEntry `json:"findings"`
+}
+
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	Address     string `json:"address"`
+	Path        string `json:"path"`
+	Name        string `json:"name"`
+	Submodule   string `json:"submodule,omitempty"`
+	SuppressionMetadata
+}
+
+// BaselineResult describes how a baseline was applied to a run.
+type BaselineResult struct {
+	File    string
+	Matched []ValidationFinding
+	Fixed   []BaselineEntry
+	Expired []BaselineEntry
+}
+
+// NewBaseline records findings; expired suppressions are left out so they cannot be re-accepted silently.
+func NewBaseline(findings []ValidationFinding) *Baseline {
+	baseline := &Baseline{Version: BaselineVersion, Entries: []BaselineEntry{}}
+	seen := make(map[string]struct{})
+
+	for _, finding := range findings {
+		if finding.Kind == FindingKindExpiredSuppression {
+			continue
+		}
+
+		fingerprint := finding.Fingerprint()
+		if _, ok := seen[fingerprint]; ok {
+			continue
+		}
+		seen[fingerprint] = struct{}{}
+
+		baseline.Entries = append(baseline.Entries, BaselineEntry{
+			Fingerprint: fingerprint,
+			Rule:        finding.RuleID(),
+			Address:     findingSubject(finding),
+			Path:        strings.ReplaceAll(finding.Path, "root.", ""),
+			Name:        finding.Name,
+			Submodule:   finding.SubmoduleName,
+		})
+	}
+
+	slices.SortFunc(baseline.Entries, func(a, b BaselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Address, b.Address),
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Rule, b.Rule),
+		)
+	})
+
+	return baseline
+}
+
+func LoadBaseline(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", path, err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+
+	if baseline.Version != BaselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %q in %s", baseline.Version, path)
+	}
+
+	for _, entry := range baseline.Entries {
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("baseline entry %s %s in %s: %w", entry.Address, entry.Name, path, err)
+		}
+	}
+
+	return &baseline, nil
+}
+
+func (baseline *Baseline) Save(path string) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+// KeepMetadata copies owner, reason and expiry from a previous baseline onto entries that are still present.
+func (baseline *Baseline) KeepMetadata(previous *Baseline) {
+	metadata := make(map[string]SuppressionMetadata, len(previous.Entries))
+	for _, entry := range previous.Entries {
+		metadata[entry.Fingerprint] = entry.SuppressionMetadata
+	}
+
+	for i := range baseline.Entries {
+		if kept, ok := metadata[baseline.Entries[i].Fingerprint]; ok {
+			baseline.Entries[i].SuppressionMetadata = kept
+		}
+	}
+}
+
+// Apply splits findings into those not covered by the baseline and reports baseline entries that no longer occur.
+// Findings whose entry has expired are kept and followed by an expired-suppression finding.
+func (baseline *Baseline) Apply(findings []ValidationFinding) ([]ValidationFinding, *BaselineResult) {
+	today := now()
+	entries := make(map[string]BaselineEntry, len(baseline.Entries))
+	seen := make(map[string]bool, len(baseline.Entries))
+	for _, entry := range baseline.Entries {
+		entries[entry.Fingerprint] = entry
+	}
+
+	result := &BaselineResult{}
+	remaining := make([]ValidationFinding, 0, len(findings))
+
+	for _, finding := range findings {
+		fingerprint := finding.Fingerprint()
+		entry, ok := entries[fingerprint]
+		if !ok {
+			remaining = append(remaining, finding)
+			continue
+		}
+
+		seen[fingerprint] = true
+		if entry.Expired(today) {
+			remaining = append(remaining, finding, expiredFinding(finding, entry.SuppressionMetadata))
+			continue
+		}
+		result.Matched = append(result.Matched, finding)
+	}
+
+	for _, entry := range baseline.Entries {
+		switch {
+		case !seen[entry.Fingerprint]:
+			result.Fixed = append(result.Fixed, entry)
+		case entry.Expired(today):
+			result.Expired = append(result.Expired, entry)
+		}
+	}
+
+	return remaining, result
+}
+
+func applyBaseline(opts *SchemaValidatorOptions, report *Report) error {
+	if opts.BaselineFile == "" {
+		return nil
+	}
+
+	baseline, err := LoadBaseline(opts.BaselineFile)
+	if err != nil {
+		return err
+	}
+
+	findings, result := baseline.Apply(report.Findings)
+	result.File = opts.BaselineFile
+	for i := range findings {
+		if findings[i].Kind == FindingKindExpiredSuppression && findings[i].Severity == "" {
+			findings[i].overrideSeverity(opts.SeverityOverrides)
+		}
+	}
+
+	report.Findings = findings
+	report.Baseline = result
+	report.reassignFindings()
+	return nil
+}
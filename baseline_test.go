@@ -0,0 +1,269 @@
+package diffy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestBaselineApply(t *testing.T) {
+	known := ValidationFinding{
+		ResourceType: "azurerm_storage_account",
+		Address:      "azurerm_storage_account.sa",
+		Path:         "root",
+		Name:         "min_tls_version",
+		Range:        hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}},
+	}
+	fixed := ValidationFinding{
+		ResourceType: "azurerm_storage_account",
+		Address:      "azurerm_storage_account.sa",
+		Path:         "root.network_rules",
+		Name:         "bypass",
+	}
+	baseline := NewBaseline([]ValidationFinding{known, fixed, known})
+
+	if len(baseline.Entries) != 2 {
+		t.Fatalf("NewBaseline() should deduplicate by fingerprint, got %d entries", len(baseline.Entries))
+	}
+
+	moved := known
+	moved.Range.Start.Line = 40
+	added := known
+	added.Name = "https_traffic_only_enabled"
+
+	remaining, result := baseline.Apply([]ValidationFinding{moved, added})
+
+	if len(remaining) != 1 || remaining[0].Name != "https_traffic_only_enabled" {
+		t.Fatalf("Apply() should only keep new findings, got %+v", remaining)
+	}
+	if len(result.Matched) != 1 || result.Matched[0].Name != "min_tls_version" {
+		t.Fatalf("Apply() should match findings regardless of line, got %+v", result.Matched)
+	}
+	if len(result.Fixed) != 1 || result.Fixed[0].Name != "bypass" || result.Fixed[0].Path != "network_rules" {
+		t.Fatalf("Apply() should report fixed entries, got %+v", result.Fixed)
+	}
+}
+
+func TestBaselineSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultBaselineFile)
+	baseline := NewBaseline([]ValidationFinding{{
+		ResourceType: "azurerm_resource_group",
+		Address:      "azurerm_resource_group.rg",
+		Path:         "root",
+		Name:         "location",
+		Required:     true,
+	}})
+
+	if err := baseline.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0] != baseline.Entries[0] {
+		t.Fatalf("LoadBaseline() = %+v, want %+v", loaded.Entries, baseline.Entries)
+	}
+	if loaded.Entries[0].Rule != RuleMissingRequiredAttribute {
+		t.Fatalf("unexpected rule %q", loaded.Entries[0].Rule)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version":"99","findings":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBaseline(path); err == nil {
+		t.Fatal("LoadBaseline() should reject unknown versions")
+	}
+
+	if _, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("LoadBaseline() should fail for a missing file")
+	}
+}
+
+func TestTextFormatterReportsBaseline(t *testing.T) {
+	report := &Report{
+		Baseline: &BaselineResult{
+			File:    DefaultBaselineFile,
+			Matched: []ValidationFinding{{Name: "tags"}},
+			Fixed: []BaselineEntry{{
+				Address: "azurerm_storage_account.sa",
+				Path:    "network_rules",
+				Name:    "bypass",
+				Rule:    RuleMissingOptionalAttribute,
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&TextFormatter{}).Format(&buf, report); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"No validation findings.",
+		"1 known findings suppressed, 1 entries fixed, 0 expired",
+		"fixed, can be pruned: azurerm_storage_account.sa network_rules.bypass (missing-optional-attribute)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestBaselineApplyExpiredEntries(t *testing.T) {
+	setNow(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	finding := ValidationFinding{
+		ResourceType: "azurerm_key_vault",
+		Address:      "azurerm_key_vault.kv",
+		Path:         "root",
+		Name:         "purge_protection_enabled",
+	}
+	active := finding
+	active.Name = "soft_delete_retention_days"
+
+	baseline := NewBaseline([]ValidationFinding{finding, active})
+	for i := range baseline.Entries {
+		if baseline.Entries[i].Name == finding.Name {
+			baseline.Entries[i].SuppressionMetadata = SuppressionMetadata{Owner: "security", Expires: "2026-05-01"}
+		} else {
+			baseline.Entries[i].SuppressionMetadata = SuppressionMetadata{Expires: "2027-01-01"}
+		}
+	}
+
+	remaining, result := baseline.Apply([]ValidationFinding{finding, active})
+
+	if len(remaining) != 2 || remaining[0].Kind != FindingKindMissing || remaining[1].RuleID() != RuleExpiredSuppression {
+		t.Fatalf("expired entries should report the finding and an expired suppression, got %+v", remaining)
+	}
+	if remaining[1].Detail != "expired on 2026-05-01, owner security" {
+		t.Fatalf("unexpected detail %q", remaining[1].Detail)
+	}
+	if len(result.Matched) != 1 || len(result.Expired) != 1 || len(result.Fixed) != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if got := NewBaseline(remaining); len(got.Entries) != 1 {
+		t.Fatalf("expired suppression findings should not be baselined, got %+v", got.Entries)
+	}
+}
+
+func TestBaselineKeepMetadata(t *testing.T) {
+	finding := ValidationFinding{ResourceType: "azurerm_resource_group", Address: "azurerm_resource_group.rg", Path: "root", Name: "tags"}
+
+	previous := NewBaseline([]ValidationFinding{finding})
+	previous.Entries[0].SuppressionMetadata = SuppressionMetadata{Owner: "platform", Reason: "legacy", Expires: "2027-01-01"}
+
+	other := finding
+	other.Name = "managed_by"
+	current := NewBaseline([]ValidationFinding{finding, other})
+	current.KeepMetadata(previous)
+
+	for _, entry := range current.Entries {
+		switch entry.Name {
+		case "tags":
+			if entry.SuppressionMetadata != previous.Entries[0].SuppressionMetadata {
+				t.Errorf("metadata should carry over, got %+v", entry.SuppressionMetadata)
+			}
+		default:
+			if entry.SuppressionMetadata != (SuppressionMetadata{}) {
+				t.Errorf("new entries should not inherit metadata, got %+v", entry.SuppressionMetadata)
+			}
+		}
+	}
+}
+
+func TestLoadBaselineRejectsInvalidExpiry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultBaselineFile)
+	if err := os.WriteFile(path, []byte(`{"version":"1","findings":[{"fingerprint":"x","expires":"soon"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBaseline(path); err == nil || !strings.Contains(err.Error(), "invalid expires date") {
+		t.Fatalf("LoadBaseline() error = %v, want invalid expires date", err)
+	}
+}
+
+func TestApplyBaselineUpdatesModules(t *testing.T) {
+	known := ValidationFinding{ResourceType: "azurerm_storage_account", Address: "azurerm_storage_account.sa", Path: "root", Name: "min_tls_version"}
+	added := ValidationFinding{ResourceType: "azurerm_subnet", Address: "module.network.azurerm_subnet.this", Path: "root", Name: "delegation", SubmoduleName: "network"}
+
+	file := filepath.Join(t.TempDir(), DefaultBaselineFile)
+	if err := NewBaseline([]ValidationFinding{known}).Save(file); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &Report{
+		Modules: []ModuleReport{
+			{
+				Status:   ModuleStatusFailed,
+				Findings: []ValidationFinding{known},
+				Entities: []EntityReport{{Address: known.Address, Findings: 1}},
+			},
+			{
+				Name:     "network",
+				Status:   ModuleStatusFailed,
+				Findings: []ValidationFinding{added},
+				Entities: []EntityReport{{Address: added.Address, Findings: 1}},
+			},
+			{Name: "broken", Status: ModuleStatusError},
+		},
+		Findings: []ValidationFinding{known, added},
+	}
+
+	if err := applyBaseline(&SchemaValidatorOptions{BaselineFile: file}, report); err != nil {
+		t.Fatalf("applyBaseline() error = %v", err)
+	}
+
+	root, network, broken := report.Modules[0], report.Modules[1], report.Modules[2]
+	if root.Status != ModuleStatusPassed || len(root.Findings) != 0 || root.Entities[0].Findings != 0 {
+		t.Errorf("root module should pass once its finding is baselined, got %+v", root)
+	}
+	if network.Status != ModuleStatusFailed || len(network.Findings) != 1 || network.Entities[0].Findings != 1 {
+		t.Errorf("network module should keep its new finding, got %+v", network)
+	}
+	if broken.Status != ModuleStatusError {
+		t.Errorf("errored modules should keep their status, got %q", broken.Status)
+	}
+}
+
+func TestApplyBaselineOverridesExpiredSeverity(t *testing.T) {
+	setNow(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	finding := ValidationFinding{ResourceType: "azurerm_storage_account", Address: "azurerm_storage_account.sa", Path: "root", Name: "min_tls_version"}
+	baseline := NewBaseline([]ValidationFinding{finding})
+	baseline.Entries[0].Expires = "2026-05-31"
+
+	file := filepath.Join(t.TempDir(), DefaultBaselineFile)
+	if err := baseline.Save(file); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &Report{
+		Modules:  []ModuleReport{{Status: ModuleStatusFailed, Findings: []ValidationFinding{finding}}},
+		Findings: []ValidationFinding{finding},
+	}
+	opts := &SchemaValidatorOptions{
+		BaselineFile:      file,
+		SeverityOverrides: map[string]Severity{RuleExpiredSuppression: SeverityNote},
+	}
+	if err := applyBaseline(opts, report); err != nil {
+		t.Fatalf("applyBaseline() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, string(f.Level())+" "+f.RuleID())
+	}
+	want := []string{"warning missing-optional-attribute", "note expired-suppression"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
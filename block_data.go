@@ -10,6 +10,7 @@ import (
 func NewBlockData() BlockData {
 	return BlockData{
 		Properties:    make(map[string]bool),
+		Attributes:    make(map[string]*hclsyntax.Attribute),
 		StaticBlocks:  make(map[string][]*ParsedBlock),
 		DynamicBlocks: make(map[string]*ParsedBlock),
 		IgnoreChanges: []string{},
@@ -17,8 +18,9 @@ func NewBlockData() BlockData {
 }
 
 func (blockData *BlockData) ParseAttributes(body *hclsyntax.Body) {
-	for name := range body.Attributes {
+	for name, attribute := range body.Attributes {
 		blockData.Properties[name] = true
+		blockData.Attributes[name] = attribute
 	}
 }
 
@@ -29,10 +31,11 @@ func (blockData *BlockData) ParseBlocks(body *hclsyntax.Body) {
 			blockData.parseLifecycle(block.Body)
 		case "dynamic":
 			if len(block.Labels) == 1 {
-				blockData.parseDynamicBlock(block.Body, block.Labels[0])
+				blockData.parseDynamicBlock(block, block.Labels[0])
 			}
 		default:
 			parsed := ParseSyntaxBody(block.Body)
+			parsed.Data.Range = block.Range()
 			blockData.StaticBlocks[block.Type] = append(blockData.StaticBlocks[block.Type], parsed)
 		}
 	}
@@ -58,10 +61,11 @@ func extractIgnoreChanges(attribute *hclsyntax.Attribute) []string {
 	return extractIgnoreChangesFromExpr(attribute.Expr)
 }
 
-func (blockData *BlockData) parseDynamicBlock(body *hclsyntax.Body, name string) {
+func (blockData *BlockData) parseDynamicBlock(block *hclsyntax.Block, name string) {
 	blockData.Properties[name] = true
-	contentBlock := findContentBlockInBody(body)
+	contentBlock := findContentBlockInBody(block.Body)
 	parsed := ParseSyntaxBody(contentBlock)
+	parsed.Data.Range = block.Range()
 	if existing := blockData.DynamicBlocks[name]; existing != nil {
 		mergeBlocks(existing, parsed)
 	} else {
@@ -107,6 +111,17 @@ func (blockData *BlockData) Validate(
 	schema *SchemaBlock,
 	parentIgnore []string,
 	findings *[]ValidationFinding,
+) {
+	blockData.ValidateWithExclusions(resourceType, path, schema, parentIgnore, AttributeExclusions{}, findings)
+}
+
+// ValidateWithExclusions validates like Validate but skips attributes and blocks matched by exclusions.
+func (blockData *BlockData) ValidateWithExclusions(
+	resourceType, path string,
+	schema *SchemaBlock,
+	parentIgnore []string,
+	exclusions AttributeExclusions,
+	findings *[]ValidationFinding,
 ) {
 	if schema == nil {
 		return
@@ -116,14 +131,93 @@ func (blockData *BlockData) Validate(
 	copy(ignore, parentIgnore)
 	ignore = append(ignore, blockData.IgnoreChanges...)
 
-	blockData.validateAttributes(resourceType, path, schema, ignore, findings)
-	blockData.validateBlocks(resourceType, path, schema, ignore, findings)
+	blockData.validateAttributes(resourceType, path, schema, ignore, exclusions, findings)
+	blockData.validateBlocks(resourceType, path, schema, ignore, exclusions, findings)
+	blockData.validateUnknown(resourceType, path, schema, exclusions, findings)
+}
+
+// metaArguments are handled by Terraform itself and never appear in a provider schema.
+var metaArguments = map[string]bool{
+	"count":       true,
+	"for_each":    true,
+	"provider":    true,
+	"depends_on":  true,
+	"lifecycle":   true,
+	"provisioner": true,
+	"connection":  true,
+}
+
+// validateUnknown reports attributes and blocks set in configuration that the schema does not define.
+func (blockData *BlockData) validateUnknown(
+	resourceType, path string,
+	schema *SchemaBlock,
+	exclusions AttributeExclusions,
+	findings *[]ValidationFinding,
+) {
+	report := func(name string, isBlock bool) {
+		if path == "root" && metaArguments[name] {
+			return
+		}
+		if exclusions.Excludes(resourceType, path, name) {
+			return
+		}
+		*findings = append(*findings, ValidationFinding{
+			Kind:         FindingKindUnknown,
+			ResourceType: resourceType,
+			Path:         path,
+			Name:         name,
+			IsBlock:      isBlock,
+			Detail:       didYouMean(suggestNames(name, schemaNames(schema, isBlock))),
+			Range:        blockData.Range,
+		})
+	}
+
+	for name := range blockData.Properties {
+		if _, ok := blockData.DynamicBlocks[name]; ok {
+			continue
+		}
+		if _, ok := schema.Attributes[name]; !ok {
+			report(name, false)
+		}
+	}
+
+	for name := range blockData.StaticBlocks {
+		if _, ok := schema.BlockTypes[name]; !ok && !attributeAsBlocks(schema.Attributes[name]) {
+			report(name, true)
+		}
+	}
+
+	for name := range blockData.DynamicBlocks {
+		if _, ok := schema.BlockTypes[name]; !ok && len(blockData.StaticBlocks[name]) == 0 && !attributeAsBlocks(schema.Attributes[name]) {
+			report(name, true)
+		}
+	}
+}
+
+// attributeAsBlocks reports whether an attribute is a list or set of objects, which providers built on the
+// legacy SDK let configurations write as repeated blocks, as with security_rule, ingress or route.
+func attributeAsBlocks(attribute *SchemaAttribute) bool {
+	if attribute == nil || attribute.Type == nil {
+		return false
+	}
+	attrType := *attribute.Type
+	return (attrType.IsListType() || attrType.IsSetType()) && attrType.ElementType().IsObjectType()
+}
+
+// setsAttribute reports whether the configuration sets an attribute, either as an argument, through a
+// dynamic block, or as blocks when the attribute is a list or set of objects.
+func (blockData *BlockData) setsAttribute(name string, attribute *SchemaAttribute) bool {
+	if blockData.Properties[name] {
+		return true
+	}
+	return len(blockData.StaticBlocks[name]) > 0 && attributeAsBlocks(attribute)
 }
 
 func (blockData *BlockData) validateAttributes(
 	resourceType, path string,
 	schema *SchemaBlock,
 	ignore []string,
+	exclusions AttributeExclusions,
 	findings *[]ValidationFinding,
 ) {
 	for name, attribute := range schema.Attributes {
@@ -131,11 +225,54 @@ func (blockData *BlockData) validateAttributes(
 			continue
 		}
 
+		if exclusions.Excludes(resourceType, path, name) {
+			continue
+		}
+
+		set := blockData.setsAttribute(name, attribute)
+
 		if attribute.Computed && !attribute.Optional && !attribute.Required {
+			if set {
+				*findings = append(*findings, ValidationFinding{
+					Kind:         FindingKindComputed,
+					ResourceType: resourceType,
+					Path:         path,
+					Name:         name,
+					Description:  attribute.Description,
+					Sensitive:    attribute.Sensitive,
+					Range:        blockData.Range,
+				})
+			}
 			continue
 		}
 
+		if assigned := blockData.Attributes[name]; assigned != nil && attribute.Type != nil {
+			if detail, ok := typeMismatch(*attribute.Type, assigned.Expr); ok {
+				*findings = append(*findings, ValidationFinding{
+					Kind:         FindingKindTypeMismatch,
+					ResourceType: resourceType,
+					Path:         path,
+					Name:         name,
+					Detail:       detail,
+					Description:  attribute.Description,
+					Sensitive:    attribute.Sensitive,
+					Range:        blockData.Range,
+				})
+			}
+		}
+
 		if attribute.Deprecated {
+			if set {
+				*findings = append(*findings, ValidationFinding{
+					Kind:         FindingKindDeprecated,
+					ResourceType: resourceType,
+					Path:         path,
+					Name:         name,
+					Description:  attribute.Description,
+					Sensitive:    attribute.Sensitive,
+					Range:        blockData.Range,
+				})
+			}
 			continue
 		}
 
@@ -143,14 +280,24 @@ func (blockData *BlockData) validateAttributes(
 			continue
 		}
 
-		if !blockData.Properties[name] {
+		if !set {
 			*findings = append(*findings, ValidationFinding{
 				ResourceType: resourceType,
 				Path:         path,
 				Name:         name,
 				Required:     attribute.Required,
 				IsBlock:      false,
+				Description:  attribute.Description,
+				Sensitive:    attribute.Sensitive,
+				Range:        blockData.Range,
 			})
+			continue
+		}
+
+		if attribute.NestedType != nil {
+			if assigned := blockData.Attributes[name]; assigned != nil {
+				validateNestedAttribute(resourceType, path+"."+name, attribute.NestedType, assigned.Expr, blockData.Range, ignore, exclusions, findings)
+			}
 		}
 	}
 }
@@ -159,20 +306,40 @@ func (blockData *BlockData) validateBlocks(
 	resourceType, path string,
 	schema *SchemaBlock,
 	ignore []string,
+	exclusions AttributeExclusions,
 	findings *[]ValidationFinding,
 ) {
 	for name, blockType := range schema.BlockTypes {
-		if name == "timeouts" || isIgnored(ignore, name) {
+		if name == "timeouts" {
 			continue
 		}
 
-		if blockType.Deprecated {
+		if exclusions.Excludes(resourceType, path, name) {
 			continue
 		}
 
 		staticBlocks := blockData.StaticBlocks[name]
 		dynamic := blockData.DynamicBlocks[name]
 
+		if blockType.Deprecated {
+			if len(staticBlocks) > 0 || dynamic != nil {
+				*findings = append(*findings, ValidationFinding{
+					Kind:         FindingKindDeprecated,
+					ResourceType: resourceType,
+					Path:         path,
+					Name:         name,
+					IsBlock:      true,
+					Description:  blockType.description(),
+					Range:        blockData.Range,
+				})
+			}
+			continue
+		}
+
+		if isIgnored(ignore, name) {
+			continue
+		}
+
 		if len(staticBlocks) == 0 && dynamic == nil {
 			*findings = append(*findings, ValidationFinding{
 				ResourceType: resourceType,
@@ -180,25 +347,83 @@ func (blockData *BlockData) validateBlocks(
 				Name:         name,
 				Required:     blockType.MinItems > 0,
 				IsBlock:      true,
+				Description:  blockType.description(),
+				Range:        blockData.Range,
 			})
 			continue
 		}
 
+		if finding, ok := cardinalityFinding(blockType, len(staticBlocks), dynamic != nil); ok {
+			finding.ResourceType = resourceType
+			finding.Path = path
+			finding.Name = name
+			finding.Description = blockType.description()
+			finding.Range = blockData.Range
+			*findings = append(*findings, finding)
+		}
+
 		for i, blk := range staticBlocks {
 			blockPath := fmt.Sprintf("%s.%s", path, name)
 			if len(staticBlocks) > 1 {
 				blockPath = fmt.Sprintf("%s.%s[%d]", path, name, i)
 			}
-			blk.Data.Validate(resourceType, blockPath, blockType.Block, ignore, findings)
+			blk.Data.ValidateWithExclusions(resourceType, blockPath, blockType.Block, ignore, exclusions, findings)
 		}
 
 		if dynamic != nil {
 			blockPath := fmt.Sprintf("%s.%s", path, name)
-			dynamic.Data.Validate(resourceType, blockPath, blockType.Block, ignore, findings)
+			dynamic.Data.ValidateWithExclusions(resourceType, blockPath, blockType.Block, ignore, exclusions, findings)
 		}
 	}
 }
 
+func (blockType *SchemaBlockType) description() string {
+	if blockType.Block == nil {
+		return ""
+	}
+	return blockType.Block.Description
+}
+
+// cardinalityFinding checks how often a block is declared against its nesting mode and item limits.
+// Dynamic blocks expand at plan time, so limits they could violate are reported as unverified instead.
+func cardinalityFinding(blockType *SchemaBlockType, static int, dynamic bool) (ValidationFinding, bool) {
+	limit := blockType.MaxItems
+	if blockType.Nesting == "single" || blockType.Nesting == "group" {
+		limit = 1
+	}
+
+	switch {
+	case limit > 0 && static > limit:
+		detail := fmt.Sprintf("%s, at most %d allowed", declaredTimes(static), limit)
+		if blockType.MaxItems == 0 {
+			detail = fmt.Sprintf("%s, %s nesting allows one", declaredTimes(static), blockType.Nesting)
+		}
+		return ValidationFinding{Kind: FindingKindCardinality, IsBlock: true, Detail: detail}, true
+	case !dynamic && static > 0 && static < blockType.MinItems:
+		return ValidationFinding{
+			Kind:     FindingKindCardinality,
+			IsBlock:  true,
+			Required: true,
+			Detail:   fmt.Sprintf("%s, at least %d required", declaredTimes(static), blockType.MinItems),
+		}, true
+	case dynamic && (limit > 0 || static < blockType.MinItems):
+		return ValidationFinding{
+			Kind:     FindingKindUnknownCardinality,
+			IsBlock:  true,
+			Required: static < blockType.MinItems,
+			Detail:   "dynamic block count cannot be checked against " + blockTypeDetail(blockType),
+		}, true
+	}
+	return ValidationFinding{}, false
+}
+
+func declaredTimes(count int) string {
+	if count == 1 {
+		return "declared once"
+	}
+	return fmt.Sprintf("declared %d times", count)
+}
+
 func isIgnored(ignore []string, name string) bool {
 	for _, item := range ignore {
 		if item == "*all*" {
@@ -216,6 +441,13 @@ func mergeBlocks(dest, src *ParsedBlock) {
 		dest.Data.Properties[key] = true
 	}
 
+	if dest.Data.Attributes == nil {
+		dest.Data.Attributes = make(map[string]*hclsyntax.Attribute, len(src.Data.Attributes))
+	}
+	for key, attribute := range src.Data.Attributes {
+		dest.Data.Attributes[key] = attribute
+	}
+
 	for key, blocks := range src.Data.StaticBlocks {
 		dest.Data.StaticBlocks[key] = append(dest.Data.StaticBlocks[key], blocks...)
 	}
@@ -1,12 +1,14 @@
 package diffy
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestNewBlockDataInitializesCollections(t *testing.T) {
@@ -15,6 +17,9 @@ func TestNewBlockDataInitializesCollections(t *testing.T) {
 	if bd.Properties == nil {
 		t.Fatalf("Properties map should be initialized")
 	}
+	if bd.Attributes == nil {
+		t.Fatalf("Attributes map should be initialized")
+	}
 	if bd.StaticBlocks == nil {
 		t.Fatalf("StaticBlocks map should be initialized")
 	}
@@ -243,3 +248,479 @@ func parseHCLBody(t *testing.T, src string) *hclsyntax.Body {
 	}
 	return body
 }
+
+func TestBlockDataValidateAttachesOwningRange(t *testing.T) {
+	body := parseHCLBody(t, `
+name = "vnet"
+
+subnet {
+  name = "a"
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name":     {Required: true},
+			"location": {Required: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"subnet": {
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{
+						"name":           {Required: true},
+						"address_prefix": {Optional: true},
+					},
+				},
+			},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_virtual_network", "root", schema, nil, &findings)
+
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+
+	for _, finding := range findings {
+		switch finding.Name {
+		case "location":
+			if finding.Range != parsed.Data.Range {
+				t.Errorf("location finding range = %v, want %v", finding.Range, parsed.Data.Range)
+			}
+		case "address_prefix":
+			if finding.Range.Start.Line != 4 || finding.Range.Filename != "test.hcl" {
+				t.Errorf("address_prefix finding should point at the subnet block, got %v", finding.Range)
+			}
+		default:
+			t.Errorf("unexpected finding %+v", finding)
+		}
+	}
+}
+
+func TestBlockDataValidateReportsDeprecatedUsage(t *testing.T) {
+	body := parseHCLBody(t, `
+name     = "vnet"
+dns_zone = "legacy"
+
+lifecycle {
+  ignore_changes = [dns_zone]
+}
+
+subnet {
+  name           = "a"
+  address_prefix = "10.0.1.0/24"
+
+  legacy_policy {}
+}
+
+dynamic "peering" {
+  for_each = var.peerings
+  content {}
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name":          {Required: true},
+			"dns_zone":      {Optional: true, Deprecated: true},
+			"flow_timeout":  {Optional: true, Deprecated: true},
+			"computed_name": {Computed: true, Deprecated: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"subnet": {
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{
+						"name":           {Required: true},
+						"address_prefix": {Optional: true, Deprecated: true},
+					},
+					BlockTypes: map[string]*SchemaBlockType{
+						"legacy_policy": {Deprecated: true, Block: &SchemaBlock{}},
+					},
+				},
+			},
+			"peering":  {Deprecated: true, Block: &SchemaBlock{}},
+			"encrypt":  {Deprecated: true, Block: &SchemaBlock{}},
+			"timeouts": {Block: &SchemaBlock{}},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_virtual_network", "root", schema, nil, &findings)
+
+	subnet := parsed.Data.StaticBlocks["subnet"][0].Data.Range
+	want := []ValidationFinding{
+		{Kind: FindingKindDeprecated, ResourceType: "azurerm_virtual_network", Path: "root", Name: "dns_zone", Range: parsed.Data.Range},
+		{Kind: FindingKindDeprecated, ResourceType: "azurerm_virtual_network", Path: "root", Name: "peering", IsBlock: true, Range: parsed.Data.Range},
+		{Kind: FindingKindDeprecated, ResourceType: "azurerm_virtual_network", Path: "root.subnet", Name: "address_prefix", Range: subnet},
+		{Kind: FindingKindDeprecated, ResourceType: "azurerm_virtual_network", Path: "root.subnet", Name: "legacy_policy", IsBlock: true, Range: subnet},
+	}
+
+	sortFindings := cmpopts.SortSlices(func(a, b ValidationFinding) bool {
+		return a.Path+"."+a.Name < b.Path+"."+b.Name
+	})
+	if diff := cmp.Diff(want, findings, sortFindings); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBlockDataValidateReportsUnknownParts(t *testing.T) {
+	body := parseHCLBody(t, `
+name                         = "st"
+publc_network_access_enabled = false
+count                        = 2
+depends_on                   = [azurerm_resource_group.rg]
+
+network_rules {
+  default_action = "Deny"
+  bypas          = ["AzureServices"]
+}
+
+dynamic "identty" {
+  for_each = var.identities
+  content {
+    type = identty.value
+  }
+}
+
+blob_propertes {}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name":                          {Required: true},
+			"public_network_access_enabled": {Optional: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"network_rules": {
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{
+						"default_action": {Required: true},
+						"bypass":         {Optional: true},
+					},
+				},
+			},
+			"identity":        {Block: &SchemaBlock{}},
+			"blob_properties": {Block: &SchemaBlock{}},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.ValidateWithExclusions("azurerm_storage_account", "root", schema, nil, AttributeExclusions{}, &findings)
+
+	var unknown []ValidationFinding
+	for _, finding := range findings {
+		if finding.Kind == FindingKindUnknown {
+			finding.Range = hcl.Range{}
+			unknown = append(unknown, finding)
+		}
+	}
+
+	want := []ValidationFinding{
+		{Kind: FindingKindUnknown, ResourceType: "azurerm_storage_account", Path: "root", Name: "blob_propertes", IsBlock: true, Detail: `did you mean "blob_properties"?`},
+		{Kind: FindingKindUnknown, ResourceType: "azurerm_storage_account", Path: "root", Name: "identty", IsBlock: true, Detail: `did you mean "identity"?`},
+		{Kind: FindingKindUnknown, ResourceType: "azurerm_storage_account", Path: "root", Name: "publc_network_access_enabled", Detail: `did you mean "public_network_access_enabled"?`},
+		{Kind: FindingKindUnknown, ResourceType: "azurerm_storage_account", Path: "root.network_rules", Name: "bypas", Detail: `did you mean "bypass"?`},
+	}
+
+	sortFindings := cmpopts.SortSlices(func(a, b ValidationFinding) bool {
+		return a.Path+"."+a.Name < b.Path+"."+b.Name
+	})
+	if diff := cmp.Diff(want, unknown, sortFindings); diff != "" {
+		t.Fatalf("unknown findings mismatch (-want +got):\n%s", diff)
+	}
+
+	var excluded []ValidationFinding
+	parsed.Data.ValidateWithExclusions("azurerm_storage_account", "root", schema, nil, NewAttributeExclusions("azurerm_storage_account.publc_*"), &excluded)
+	for _, finding := range excluded {
+		if finding.Name == "publc_network_access_enabled" {
+			t.Fatalf("excluded unknown attribute should not be reported: %+v", finding)
+		}
+	}
+}
+
+func TestBlockDataValidateAcceptsAttributesAsBlocks(t *testing.T) {
+	body := parseHCLBody(t, `
+name = "nsg"
+
+security_rule {
+  name     = "allow-https"
+  priority = 100
+}
+
+dynamic "route" {
+  for_each = var.routes
+  content {
+    name = route.key
+  }
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	rule := cty.Object(map[string]cty.Type{"name": cty.String, "priority": cty.Number})
+	securityRule := cty.Set(rule)
+	routes := cty.List(rule)
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name":          {Required: true},
+			"security_rule": {Optional: true, Computed: true, Type: &securityRule},
+			"route":         {Optional: true, Type: &routes},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_network_security_group", "root", schema, nil, &findings)
+	if len(findings) != 0 {
+		t.Fatalf("expected blocks for list and set of object attributes to be accepted, got %+v", findings)
+	}
+
+	tags := cty.Map(cty.String)
+	schema.Attributes["tags"] = &SchemaAttribute{Optional: true, Type: &tags}
+	tagsBody := parseHCLBody(t, `
+name = "nsg"
+
+tags {
+  env = "dev"
+}
+`)
+
+	findings = nil
+	ParseSyntaxBody(tagsBody).Data.Validate("azurerm_network_security_group", "root", schema, nil, &findings)
+	want := []ValidationFinding{
+		{Kind: FindingKindUnknown, ResourceType: "azurerm_network_security_group", Path: "root", Name: "tags", IsBlock: true},
+		{ResourceType: "azurerm_network_security_group", Path: "root", Name: "route"},
+		{ResourceType: "azurerm_network_security_group", Path: "root", Name: "security_rule"},
+		{ResourceType: "azurerm_network_security_group", Path: "root", Name: "tags"},
+	}
+	sortFindings := cmpopts.SortSlices(func(a, b ValidationFinding) bool {
+		return string(a.Kind)+a.Name < string(b.Kind)+b.Name
+	})
+	if diff := cmp.Diff(want, findings, sortFindings, cmpopts.IgnoreFields(ValidationFinding{}, "Range", "Detail")); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBlockDataValidateReportsComputedOnlyAssignments(t *testing.T) {
+	body := parseHCLBody(t, `
+name                  = "st"
+primary_blob_endpoint = "https://example"
+
+dynamic "network_rules" {
+  for_each = var.rules
+  content {
+    default_action = "Deny"
+    rule_id        = network_rules.key
+  }
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name":                  {Required: true},
+			"primary_blob_endpoint": {Computed: true},
+			"primary_access_key":    {Computed: true},
+			"account_tier":          {Optional: true, Computed: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"network_rules": {
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{
+						"default_action": {Required: true},
+						"rule_id":        {Computed: true},
+					},
+				},
+			},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_storage_account", "root", schema, nil, &findings)
+
+	rules := parsed.Data.DynamicBlocks["network_rules"].Data.Range
+	want := []ValidationFinding{
+		{ResourceType: "azurerm_storage_account", Path: "root", Name: "account_tier", Range: parsed.Data.Range},
+		{Kind: FindingKindComputed, ResourceType: "azurerm_storage_account", Path: "root", Name: "primary_blob_endpoint", Range: parsed.Data.Range},
+		{Kind: FindingKindComputed, ResourceType: "azurerm_storage_account", Path: "root.network_rules", Name: "rule_id", Range: rules},
+	}
+
+	sortFindings := cmpopts.SortSlices(func(a, b ValidationFinding) bool {
+		return a.Path+"."+a.Name < b.Path+"."+b.Name
+	})
+	if diff := cmp.Diff(want, findings, sortFindings); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCardinalityFinding(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockType SchemaBlockType
+		static    int
+		dynamic   bool
+		want      ValidationFinding
+		wantOK    bool
+	}{
+		{name: "within limits", blockType: SchemaBlockType{Nesting: "list", MinItems: 1, MaxItems: 2}, static: 2},
+		{name: "unbounded list", blockType: SchemaBlockType{Nesting: "list"}, static: 5},
+		{name: "absent is reported as missing instead", blockType: SchemaBlockType{Nesting: "list", MinItems: 2}},
+		{
+			name:      "too many",
+			blockType: SchemaBlockType{Nesting: "list", MaxItems: 1},
+			static:    2,
+			want:      ValidationFinding{Kind: FindingKindCardinality, IsBlock: true, Detail: "declared 2 times, at most 1 allowed"},
+			wantOK:    true,
+		},
+		{
+			name:      "too few",
+			blockType: SchemaBlockType{Nesting: "set", MinItems: 2},
+			static:    1,
+			want:      ValidationFinding{Kind: FindingKindCardinality, IsBlock: true, Required: true, Detail: "declared once, at least 2 required"},
+			wantOK:    true,
+		},
+		{
+			name:      "single nesting repeated",
+			blockType: SchemaBlockType{Nesting: "single"},
+			static:    2,
+			want:      ValidationFinding{Kind: FindingKindCardinality, IsBlock: true, Detail: "declared 2 times, single nesting allows one"},
+			wantOK:    true,
+		},
+		{
+			name:      "dynamic with limit",
+			blockType: SchemaBlockType{Nesting: "list", MaxItems: 1},
+			dynamic:   true,
+			want:      ValidationFinding{Kind: FindingKindUnknownCardinality, IsBlock: true, Detail: "dynamic block count cannot be checked against list, max 1"},
+			wantOK:    true,
+		},
+		{
+			name:      "dynamic below minimum",
+			blockType: SchemaBlockType{Nesting: "list", MinItems: 1},
+			dynamic:   true,
+			want:      ValidationFinding{Kind: FindingKindUnknownCardinality, IsBlock: true, Required: true, Detail: "dynamic block count cannot be checked against list, min 1"},
+			wantOK:    true,
+		},
+		{name: "dynamic with static minimum met", blockType: SchemaBlockType{Nesting: "list", MinItems: 1}, static: 1, dynamic: true},
+		{name: "dynamic unbounded", blockType: SchemaBlockType{Nesting: "set"}, dynamic: true},
+		{
+			name:      "static already over limit with dynamic",
+			blockType: SchemaBlockType{Nesting: "list", MaxItems: 1},
+			static:    2,
+			dynamic:   true,
+			want:      ValidationFinding{Kind: FindingKindCardinality, IsBlock: true, Detail: "declared 2 times, at most 1 allowed"},
+			wantOK:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cardinalityFinding(&tt.blockType, tt.static, tt.dynamic)
+			if ok != tt.wantOK {
+				t.Fatalf("cardinalityFinding() ok = %v, want %v (%+v)", ok, tt.wantOK, got)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("cardinalityFinding() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBlockDataValidateReportsBlockCardinality(t *testing.T) {
+	body := parseHCLBody(t, `
+identity {
+  type = "SystemAssigned"
+}
+
+identity {
+  type = "UserAssigned"
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	schema := &SchemaBlock{
+		BlockTypes: map[string]*SchemaBlockType{
+			"identity": {
+				Nesting:  "list",
+				MaxItems: 1,
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{"type": {Required: true}},
+				},
+			},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_storage_account", "root", schema, nil, &findings)
+
+	want := []ValidationFinding{{
+		Kind:         FindingKindCardinality,
+		ResourceType: "azurerm_storage_account",
+		Path:         "root",
+		Name:         "identity",
+		IsBlock:      true,
+		Detail:       "declared 2 times, at most 1 allowed",
+		Range:        parsed.Data.Range,
+	}}
+	if diff := cmp.Diff(want, findings); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := FormatFinding(ValidationFinding{Kind: FindingKindCardinality, ResourceType: "azurerm_storage_account", Address: "azurerm_storage_account.st", Path: "root", Name: "identity", IsBlock: true, Detail: want[0].Detail}); got != "azurerm_storage_account.st: invalid number of block identity in root (resource) - declared 2 times, at most 1 allowed" {
+		t.Fatalf("FormatFinding() = %q", got)
+	}
+}
+
+func TestBlockDataValidateCarriesSchemaDescriptions(t *testing.T) {
+	body := parseHCLBody(t, `
+name = "st"
+`)
+	parsed := ParseSyntaxBody(body)
+
+	var schema ResourceSchema
+	err := json.Unmarshal([]byte(`{
+  "block": {
+    "attributes": {
+      "name": {"type": "string", "required": true, "description": "The name of the account.", "description_kind": "plain"},
+      "access_key": {"type": "string", "required": true, "sensitive": true, "description": "The primary access key.", "description_kind": "markdown"}
+    },
+    "block_types": {
+      "network_rules": {
+        "nesting_mode": "list",
+        "block": {"description": "Network rules restricting access to the account.", "description_kind": "plain"}
+      }
+    },
+    "description": "Manages a storage account.",
+    "description_kind": "plain"
+  }
+}`), &schema)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if schema.Block.Description != "Manages a storage account." || schema.Block.Attributes["access_key"].DescriptionKind != "markdown" {
+		t.Fatalf("schema descriptions not decoded: %+v", schema.Block)
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_storage_account", "root", schema.Block, nil, &findings)
+
+	explanations := map[string]string{}
+	for _, finding := range findings {
+		explanations[finding.Name] = finding.Explanation()
+	}
+
+	want := map[string]string{
+		"access_key":    "(sensitive) The primary access key.",
+		"network_rules": "Network rules restricting access to the account.",
+	}
+	if diff := cmp.Diff(want, explanations); diff != "" {
+		t.Fatalf("explanations mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,83 @@
+package diffy
+
+import (
+	"encoding/xml"
+	"io"
+	"maps"
+	"slices"
+)
+
+type CheckstyleFormatter struct{}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (f *CheckstyleFormatter) Format(w io.Writer, report *Report) error {
+	files := make(map[string]*checkstyleFile)
+
+	for _, finding := range report.Findings {
+		name := displayPath(moduleMainFile(report, finding.SubmoduleName))
+		entry := checkstyleError{
+			Line:     1,
+			Severity: checkstyleSeverity(finding.Level()),
+			Message:  FormatFinding(finding),
+			Source:   "diffy." + finding.RuleID(),
+		}
+		if finding.Range.Filename != "" {
+			name = displayPath(finding.Range.Filename)
+			entry.Line = finding.Range.Start.Line
+			entry.Column = finding.Range.Start.Column
+		}
+
+		file, ok := files[name]
+		if !ok {
+			file = &checkstyleFile{Name: name}
+			files[name] = file
+		}
+		file.Errors = append(file.Errors, entry)
+	}
+
+	out := checkstyleReport{Version: "8.0"}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		out.Files = append(out.Files, *files[name])
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func checkstyleSeverity(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityNote:
+		return "info"
+	default:
+		return "warning"
+	}
+}
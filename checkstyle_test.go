@@ -0,0 +1,84 @@
+package diffy
+
+import (
+	"bytes"
+	"encoding/xml"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestCheckstyleFormatter(t *testing.T) {
+	report := &Report{
+		Root:    "infra",
+		Modules: []ModuleReport{{Path: "infra"}, {Name: "network", Path: "infra/modules/network"}},
+		Findings: []ValidationFinding{
+			{
+				ResourceType: "azurerm_subnet",
+				Address:      "module.network.azurerm_subnet.this",
+				Path:         "root",
+				Name:         "name",
+				Required:     true,
+				Range:        hcl.Range{Filename: "modules/network/main.tf", Start: hcl.Pos{Line: 3, Column: 1}},
+			},
+			{
+				ResourceType: "azurerm_resource_group",
+				Address:      "azurerm_resource_group.rg",
+				Path:         "root",
+				Name:         "tags",
+				Range:        hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}},
+			},
+			{
+				ResourceType: "azurerm_resource_group",
+				Address:      "azurerm_resource_group.rg",
+				Path:         "root",
+				Name:         "managed_by",
+				Range:        hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}},
+			},
+			{ResourceType: "azurerm_key_vault", Path: "root", Name: "tenant_id", Required: true, IsBlock: true},
+			{ResourceType: "azurerm_subnet", Path: "root", Name: "delegation", IsBlock: true, SubmoduleName: "network"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatCheckstyle, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	var decoded checkstyleReport
+	if err := xml.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out.String())
+	}
+
+	if len(decoded.Files) != 4 {
+		t.Fatalf("expected findings grouped into 4 files, got %+v", decoded.Files)
+	}
+
+	var names []string
+	for _, file := range decoded.Files {
+		names = append(names, file.Name)
+	}
+	wantNames := []string{"infra/main.tf", "infra/modules/network/main.tf", "main.tf", "modules/network/main.tf"}
+	if !slices.Equal(names, wantNames) {
+		t.Fatalf("files should be sorted by name, got %v", names)
+	}
+
+	if len(decoded.Files[2].Errors) != 2 {
+		t.Errorf("main.tf should carry both of its findings, got %+v", decoded.Files[2].Errors)
+	}
+
+	subnet := decoded.Files[3].Errors[0]
+	if subnet.Line != 3 || subnet.Severity != "error" || subnet.Source != "diffy."+RuleMissingRequiredAttribute {
+		t.Errorf("unexpected error entry: %+v", subnet)
+	}
+	if tags := decoded.Files[2].Errors[0]; tags.Severity != "warning" || tags.Source != "diffy."+RuleMissingOptionalAttribute {
+		t.Errorf("unexpected optional entry: %+v", tags)
+	}
+	if block := decoded.Files[0].Errors[0]; block.Line != 1 || block.Source != "diffy."+RuleMissingRequiredBlock {
+		t.Errorf("finding without range should fall back to line 1 of the root main.tf: %+v", block)
+	}
+	if delegation := decoded.Files[1].Errors[0]; delegation.Line != 1 || delegation.Source != "diffy."+RuleMissingOptionalBlock {
+		t.Errorf("submodule finding without range should fall back to its own main.tf: %+v", delegation)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dkooll/diffy"
+)
+
+func explainResource(w io.Writer, resourceType, source string, kind diffy.EntityKind, schema *diffy.ResourceSchema) error {
+	if _, err := fmt.Fprintf(w, "%s (%s, %s)\n", resourceType, kind, source); err != nil {
+		return err
+	}
+
+	if schema == nil || schema.Block == nil {
+		return nil
+	}
+
+	var b strings.Builder
+	explainBlock(&b, schema.Block, 1)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func explainBlock(b *strings.Builder, block *diffy.SchemaBlock, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	for _, name := range slices.Sorted(maps.Keys(block.Attributes)) {
+		attribute := block.Attributes[name]
+		fmt.Fprintf(b, "%s%s  %s\n", indent, name, attributeFlags(attribute))
+		if attribute.NestedType != nil {
+			explainBlock(b, &diffy.SchemaBlock{Attributes: attribute.NestedType.Attributes}, depth+1)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(block.BlockTypes)) {
+		blockType := block.BlockTypes[name]
+		fmt.Fprintf(b, "%s%s {}  %s\n", indent, name, blockTypeFlags(blockType))
+		if blockType.Block != nil {
+			explainBlock(b, blockType.Block, depth+1)
+		}
+	}
+}
+
+func attributeFlags(attribute *diffy.SchemaAttribute) string {
+	var flags []string
+	switch {
+	case attribute.Required:
+		flags = append(flags, "required")
+	case attribute.Optional:
+		flags = append(flags, "optional")
+	}
+	if attribute.Computed {
+		flags = append(flags, "computed")
+	}
+	if attribute.Deprecated {
+		flags = append(flags, "deprecated")
+	}
+	if attribute.Type != nil {
+		flags = append(flags, attribute.Type.FriendlyName())
+	}
+	if attribute.NestedType != nil && attribute.NestedType.NestingMode != "" {
+		flags = append(flags, "nested "+attribute.NestedType.NestingMode)
+	}
+	return strings.Join(flags, ", ")
+}
+
+func blockTypeFlags(blockType *diffy.SchemaBlockType) string {
+	flags := []string{}
+	if blockType.Nesting != "" {
+		flags = append(flags, blockType.Nesting)
+	}
+	if blockType.MinItems > 0 {
+		flags = append(flags, fmt.Sprintf("min %d", blockType.MinItems))
+	}
+	if blockType.MaxItems > 0 {
+		flags = append(flags, fmt.Sprintf("max %d", blockType.MaxItems))
+	}
+	if blockType.Deprecated {
+		flags = append(flags, "deprecated")
+	}
+	return strings.Join(flags, ", ")
+}
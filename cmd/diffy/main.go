@@ -0,0 +1,370 @@
+// Command diffy validates Terraform configurations against provider schemas.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dkooll/diffy"
+)
+
+const (
+	exitClean    = 0
+	exitFindings = 1
+	exitError    = 2
+)
+
+const usage = `Usage: diffy <command> [flags]
+
+Commands:
+  validate                 validate a Terraform root and its submodules
+  baseline                 record the current findings so later runs only report new ones
+  explain <resource_type>  describe the provider schema of a resource or data source
+  schema dump              print the provider schemas resolved for the Terraform root
+
+Run 'diffy <command> -h' for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitError
+	}
+
+	switch args[0] {
+	case "validate":
+		return runValidate(args[1:], stdout, stderr)
+	case "baseline":
+		return runBaseline(args[1:], stdout, stderr)
+	case "explain":
+		return runExplain(args[1:], stdout, stderr)
+	case "schema":
+		return runSchema(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitClean
+	default:
+		fmt.Fprintf(stderr, "diffy: unknown command %q\n\n%s", args[0], usage)
+		return exitError
+	}
+}
+
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+type commonFlags struct {
+	root   string
+	config string
+}
+
+func (c *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.root, "root", ".", "path to the Terraform root (TERRAFORM_ROOT takes precedence)")
+	fs.StringVar(&c.config, "config", "", "project configuration file (defaults to "+diffy.ProjectConfigFile+" in the Terraform root)")
+}
+
+func (c *commonFlags) options(stderr io.Writer) []diffy.SchemaValidatorOption {
+	return []diffy.SchemaValidatorOption{
+		diffy.WithTerraformRoot(c.root),
+		diffy.WithConfigFile(c.config),
+		func(opts *diffy.SchemaValidatorOptions) {
+			opts.Logger = &diffy.SimpleLogger{Writer: stderr}
+		},
+	}
+}
+
+type validateFlags struct {
+	commonFlags
+	format              string
+	output              string
+	silent              bool
+	baseline            string
+	excludedResources   listFlag
+	excludedDataSources listFlag
+	excludedEphemeral   listFlag
+	excludedAttributes  listFlag
+	githubIssue         bool
+	githubActions       bool
+	githubToken         string
+	githubOwner         string
+	githubRepo          string
+}
+
+func (v *validateFlags) register(fs *flag.FlagSet) {
+	v.commonFlags.register(fs)
+	fs.StringVar(&v.format, "format", diffy.FormatText, "output format ("+strings.Join(diffy.SupportedFormats(), ", ")+")")
+	fs.StringVar(&v.output, "output", "", "write the report to this file instead of stdout")
+	fs.BoolVar(&v.silent, "silent", false, "suppress the findings report on stdout")
+	fs.StringVar(&v.baseline, "baseline", "", "only report findings that are not recorded in this baseline file")
+	fs.Var(&v.excludedResources, "exclude-resources", "comma-separated resource types to skip (repeatable)")
+	fs.Var(&v.excludedDataSources, "exclude-data-sources", "comma-separated data source types to skip (repeatable)")
+	fs.Var(&v.excludedEphemeral, "exclude-ephemeral-resources", "comma-separated ephemeral resource types to skip (repeatable)")
+	fs.Var(&v.excludedAttributes, "exclude-attributes", "comma-separated attribute glob patterns to skip, e.g. '*.tags' (repeatable)")
+	fs.BoolVar(&v.githubIssue, "github-issue", false, "create or update a GitHub issue with the findings")
+	fs.BoolVar(&v.githubActions, "github-actions", false, "emit workflow annotations and a step summary (automatic when GITHUB_ACTIONS=true)")
+	fs.StringVar(&v.githubToken, "github-token", "", "GitHub token for issue creation (defaults to GITHUB_TOKEN)")
+	fs.StringVar(&v.githubOwner, "github-owner", "", "GitHub repository owner (defaults to the Actions environment)")
+	fs.StringVar(&v.githubRepo, "github-repo", "", "GitHub repository name (defaults to the Actions environment)")
+}
+
+// options only overrides settings whose flags were given, so values from the project configuration survive.
+func (v *validateFlags) options(fs *flag.FlagSet, stdout, stderr io.Writer) []diffy.SchemaValidatorOption {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	options := v.commonFlags.options(stderr)
+	options = append(options,
+		diffy.WithOutput(stdout),
+		diffy.WithBaseline(v.baseline),
+		diffy.WithExcludedResources(v.excludedResources...),
+		diffy.WithExcludedDataSources(v.excludedDataSources...),
+		diffy.WithExcludedEphemeralResources(v.excludedEphemeral...),
+		diffy.WithExcludedAttributes(v.excludedAttributes...),
+	)
+
+	if set["format"] {
+		options = append(options, diffy.WithOutputFormat(v.format))
+	}
+	if set["output"] {
+		options = append(options, diffy.WithOutputFile(v.output))
+	}
+
+	if v.githubIssue {
+		options = append(options, diffy.WithGitHubIssueCreation())
+	}
+	if v.githubActions {
+		options = append(options, diffy.WithGitHubActions())
+	}
+
+	options = append(options, func(opts *diffy.SchemaValidatorOptions) {
+		opts.Silent = v.silent
+		if v.githubToken != "" {
+			opts.GitHubToken = v.githubToken
+		}
+		if v.githubOwner != "" {
+			opts.GitHubOwner = v.githubOwner
+		}
+		if v.githubRepo != "" {
+			opts.GitHubRepo = v.githubRepo
+		}
+	})
+
+	return options
+}
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	var flags validateFlags
+	fs := newFlagSet("validate", "diffy validate [flags]", stderr)
+	flags.register(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err)
+	}
+
+	report, err := diffy.ValidateSchemaReport(flags.options(fs, stdout, stderr)...)
+	if err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	if errored := report.ErroredModules(); len(errored) > 0 {
+		for _, module := range errored {
+			fmt.Fprintf(stderr, "diffy: module %s could not be validated: %s\n", module.DisplayName(), module.Error)
+		}
+		return exitError
+	}
+
+	if report.Failed() {
+		return exitFindings
+	}
+	return exitClean
+}
+
+func runBaseline(args []string, stdout, stderr io.Writer) int {
+	var flags commonFlags
+	var file string
+	var excludedResources, excludedDataSources, excludedEphemeral, excludedAttributes listFlag
+	fs := newFlagSet("baseline", "diffy baseline [flags]", stderr)
+	flags.register(fs)
+	var metadata diffy.SuppressionMetadata
+	fs.StringVar(&file, "file", diffy.DefaultBaselineFile, "baseline file to write")
+	fs.StringVar(&metadata.Owner, "owner", "", "owner recorded on new baseline entries")
+	fs.StringVar(&metadata.Reason, "reason", "", "reason recorded on new baseline entries")
+	fs.StringVar(&metadata.Expires, "expires", "", "expiry date (YYYY-MM-DD) recorded on new baseline entries")
+	fs.Var(&excludedResources, "exclude-resources", "comma-separated resource types to skip (repeatable)")
+	fs.Var(&excludedDataSources, "exclude-data-sources", "comma-separated data source types to skip (repeatable)")
+	fs.Var(&excludedEphemeral, "exclude-ephemeral-resources", "comma-separated ephemeral resource types to skip (repeatable)")
+	fs.Var(&excludedAttributes, "exclude-attributes", "comma-separated attribute glob patterns to skip (repeatable)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err)
+	}
+	if err := metadata.Validate(); err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	options := append(flags.options(stderr),
+		diffy.WithExcludedResources(excludedResources...),
+		diffy.WithExcludedDataSources(excludedDataSources...),
+		diffy.WithExcludedEphemeralResources(excludedEphemeral...),
+		diffy.WithExcludedAttributes(excludedAttributes...),
+		diffy.WithBaseline(""),
+	)
+
+	report, err := diffy.CollectReport(options...)
+	if err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	baseline := diffy.NewBaseline(report.Findings)
+	for i := range baseline.Entries {
+		baseline.Entries[i].SuppressionMetadata = metadata
+	}
+	previous, err := diffy.LoadBaseline(file)
+	switch {
+	case err == nil:
+		baseline.KeepMetadata(previous)
+	case !errors.Is(err, os.ErrNotExist):
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	if err := baseline.Save(file); err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(stdout, "Recorded %d findings in %s\n", len(baseline.Entries), file)
+	return exitClean
+}
+
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	var flags commonFlags
+	var dataSource, ephemeral bool
+	fs := newFlagSet("explain", "diffy explain [flags] <resource_type>", stderr)
+	flags.register(fs)
+	fs.BoolVar(&dataSource, "data", false, "explain a data source instead of a resource")
+	fs.BoolVar(&ephemeral, "ephemeral", false, "explain an ephemeral resource instead of a resource")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+
+	if len(positional) != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	kind := diffy.EntityKindResource
+	switch {
+	case dataSource && ephemeral:
+		fmt.Fprintf(stderr, "diffy: --data and --ephemeral cannot be combined\n")
+		return exitError
+	case dataSource:
+		kind = diffy.EntityKindDataSource
+	case ephemeral:
+		kind = diffy.EntityKindEphemeralResource
+	}
+
+	schema, err := diffy.LoadSchema(flags.options(stderr)...)
+	if err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	resourceType := positional[0]
+	source, resSchema, ok := diffy.FindEntitySchema(schema, resourceType, kind)
+	if !ok {
+		fmt.Fprintf(stderr, "diffy: no %s schema found for %s\n", kind, resourceType)
+		return exitError
+	}
+
+	if err := explainResource(stdout, resourceType, source, kind, resSchema); err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+	return exitClean
+}
+
+func runSchema(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "dump" {
+		fmt.Fprintf(stderr, "Usage: diffy schema dump [flags]\n")
+		return exitError
+	}
+
+	var flags commonFlags
+	fs := newFlagSet("schema dump", "diffy schema dump [flags]", stderr)
+	flags.register(fs)
+	if _, err := parseFlags(fs, args[1:]); err != nil {
+		return flagExitCode(err)
+	}
+
+	schema, err := diffy.LoadSchema(flags.options(stderr)...)
+	if err != nil {
+		fmt.Fprintf(stderr, "diffy: %v\n", err)
+		return exitError
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(schema); err != nil {
+		fmt.Fprintf(stderr, "diffy: failed to encode schema: %v\n", err)
+		return exitError
+	}
+	return exitClean
+}
+
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s\n\nFlags:\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags accepts flags before and after positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func flagExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitClean
+	}
+	return exitError
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/diffy"
+)
+
+const fakeSchema = `{"provider_schemas":{"registry.terraform.io/hashicorp/azurerm":{"resource_schemas":{"azurerm_resource_group":{"block":{"attributes":{"id":{"computed":true},"name":{"required":true},"location":{"required":true},"tags":{"optional":true,"type":["map","string"]}},"block_types":{"timeouts":{"nesting":"single","block":{"attributes":{"create":{"optional":true}}}}}}}},"data_source_schemas":{}}}}`
+
+func TestRunValidateExitCodes(t *testing.T) {
+	installFakeTerraform(t)
+
+	tests := []struct {
+		name     string
+		resource string
+		wantCode int
+		wantOut  string
+	}{
+		{
+			name: "clean",
+			resource: `resource "azurerm_resource_group" "rg" {
+  name     = "rg"
+  location = "westeurope"
+  tags     = {}
+}`,
+			wantCode: exitClean,
+			wantOut:  "No validation findings.",
+		},
+		{
+			name: "findings",
+			resource: `resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}`,
+			wantCode: exitFindings,
+			wantOut:  "missing required property location",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeModule(t, tt.resource)
+
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"validate", "--root", root}, &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Fatalf("stdout %q should contain %q", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestRunValidateFailsOnBrokenSubmodule(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name     = "rg"
+  location = "westeurope"
+  tags     = {}
+}`)
+	broken := filepath.Join(root, "modules", "broken")
+	if err := os.MkdirAll(broken, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "main.tf"), []byte(`resource "azurerm_resource_group" {`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate", "--root", root}, &stdout, &stderr); code != exitError {
+		t.Fatalf("run() = %d, want %d (stdout: %s)", code, exitError, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "module broken could not be validated") {
+		t.Fatalf("stderr should name the broken module, got %q", stderr.String())
+	}
+}
+
+func TestRunValidatePassesWithOnlyNotes(t *testing.T) {
+	schema := strings.Replace(fakeSchema, `"block_types":{`, `"block_types":{"identity":{"nesting":"list","max_items":1,"block":{"attributes":{"type":{"required":true}}}},`, 1)
+	installFakeTerraformSchema(t, schema)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name     = "rg"
+  location = "westeurope"
+  tags     = {}
+
+  dynamic "identity" {
+    for_each = var.identities
+    content {
+      type = identity.value
+    }
+  }
+}`)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate", "--root", root}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("run() = %d, want %d (stdout: %s)", code, exitClean, stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "dynamic block count cannot be checked") {
+		t.Fatalf("the note should still be reported, got %q", stdout.String())
+	}
+}
+
+func TestRunValidateWritesJSONReportFile(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}`)
+	output := filepath.Join(t.TempDir(), "report.json")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"validate", "--root", root, "--format", "json", "--output", output}, &stdout, &stderr)
+	if code != exitFindings {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitFindings, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("report should go to the file only, stdout got %q", stdout.String())
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	var report struct {
+		SchemaVersion string `json:"schema_version"`
+		Findings      []struct {
+			Address string `json:"address"`
+			Name    string `json:"name"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	if report.SchemaVersion == "" || len(report.Findings) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Findings[0].Address != "azurerm_resource_group.rg" {
+		t.Fatalf("unexpected finding address %q", report.Findings[0].Address)
+	}
+}
+
+func TestRunValidateUsesProjectConfig(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}`)
+	config := `output {
+  format = "json"
+}
+
+resource "azurerm_resource_group" {
+  ignore = ["tags"]
+}
+`
+	if err := os.WriteFile(filepath.Join(root, ".diffy.hcl"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate", "--root", root}, &stdout, &stderr); code != exitFindings {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitFindings, stderr.String())
+	}
+
+	var report struct {
+		Findings []struct {
+			Name string `json:"name"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("the configured json format should be used: %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Name != "location" {
+		t.Fatalf("unexpected findings %+v", report.Findings)
+	}
+
+	stdout.Reset()
+	run([]string{"validate", "--root", root, "--format", "text"}, &stdout, &stderr)
+	if !strings.HasPrefix(stdout.String(), "Found 1 issues:") {
+		t.Fatalf("--format should override the configuration, got %q", stdout.String())
+	}
+}
+
+func TestRunBaseline(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}`)
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"baseline", "--root", root, "--file", baseline}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("baseline run() = %d, want %d (stderr: %s)", code, exitClean, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Recorded 2 findings") {
+		t.Fatalf("unexpected baseline output %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"validate", "--root", root, "--baseline", baseline}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("validate with baseline run() = %d, want %d (stdout: %s)", code, exitClean, stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "2 known findings suppressed") {
+		t.Fatalf("unexpected validate output %q", stdout.String())
+	}
+
+	mainFile := filepath.Join(root, "main.tf")
+	content, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content = bytes.Replace(content, []byte(`name = "rg"`), []byte(`location = "westeurope"`), 1)
+	if err := os.WriteFile(mainFile, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"validate", "--root", root, "--baseline", baseline}, &stdout, &stderr); code != exitFindings {
+		t.Fatalf("new findings should fail, got %d (stdout: %s)", code, stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "missing required property name") || !strings.Contains(stdout.String(), "fixed, can be pruned") {
+		t.Fatalf("unexpected validate output %q", stdout.String())
+	}
+}
+
+func TestRunBaselineWritesNoReports(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}`)
+	report := filepath.Join(t.TempDir(), "report.sarif")
+	config := "output {\n  format = \"sarif\"\n  file   = \"" + filepath.ToSlash(report) + "\"\n}\n"
+	if err := os.WriteFile(filepath.Join(root, diffy.ProjectConfigFile), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+
+	var stdout, stderr bytes.Buffer
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	if code := run([]string{"baseline", "--root", root, "--file", baseline}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitClean, stderr.String())
+	}
+
+	for _, path := range []string{report, summary} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("baseline should not write %s, got %v", path, err)
+		}
+	}
+	if strings.Contains(stdout.String(), "::") {
+		t.Errorf("baseline should not emit workflow annotations, got %q", stdout.String())
+	}
+}
+
+func TestRunBaselineRejectsUnreadableBaseline(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, `resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}`)
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	corrupt := []byte(`{"version": "0", "findings": []}`)
+	if err := os.WriteFile(baseline, corrupt, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"baseline", "--root", root, "--file", baseline}, &stdout, &stderr); code != exitError {
+		t.Fatalf("run() = %d, want %d", code, exitError)
+	}
+	content, err := os.ReadFile(baseline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, corrupt) {
+		t.Fatalf("an unreadable baseline should be left untouched, got %s", content)
+	}
+}
+
+func TestRunValidateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"lint"}},
+		{name: "unknown format", args: []string{"validate", "--root", t.TempDir(), "--format", "yaml"}},
+		{name: "unknown flag", args: []string{"validate", "--nope"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != exitError {
+				t.Fatalf("run(%v) = %d, want %d", tt.args, code, exitError)
+			}
+		})
+	}
+}
+
+func TestRunExplain(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, "")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"explain", "azurerm_resource_group", "--root", root}, &stdout, &stderr)
+	if code != exitClean {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitClean, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"azurerm_resource_group (resource, registry.terraform.io/hashicorp/azurerm)",
+		"location  required",
+		"tags  optional, map of string",
+		"timeouts {}  single",
+		"    create  optional",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("explain output should contain %q, got:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"explain", "--root", root, "--data", "azurerm_resource_group"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("explaining an unknown data source should fail, got %d", code)
+	}
+}
+
+func TestRunExplainEphemeral(t *testing.T) {
+	schema := strings.Replace(fakeSchema, `"data_source_schemas":{}`, `"data_source_schemas":{},"ephemeral_resource_schemas":{"azurerm_key_vault_secret":{"block":{"attributes":{"name":{"required":true}}}}}`, 1)
+	installFakeTerraformSchema(t, schema)
+	root := writeModule(t, "")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"explain", "--root", root, "--ephemeral", "azurerm_key_vault_secret"}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitClean, stderr.String())
+	}
+	if want := "azurerm_key_vault_secret (ephemeral resource, registry.terraform.io/hashicorp/azurerm)"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("explain output should contain %q, got:\n%s", want, stdout.String())
+	}
+
+	stderr.Reset()
+	if code := run([]string{"explain", "--root", root, "azurerm_key_vault_secret"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("an ephemeral resource should not resolve as a resource, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "no resource schema found for azurerm_key_vault_secret") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+
+	if code := run([]string{"explain", "--root", root, "--data", "--ephemeral", "azurerm_key_vault_secret"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("--data and --ephemeral together should fail, got %d", code)
+	}
+}
+
+func TestExplainBlockNestedAttributes(t *testing.T) {
+	block := &diffy.SchemaBlock{
+		Attributes: map[string]*diffy.SchemaAttribute{
+			"rules": {Optional: true, NestedType: &diffy.SchemaNestedType{
+				NestingMode: "list",
+				Attributes: map[string]*diffy.SchemaAttribute{
+					"port": {Required: true},
+				},
+			}},
+		},
+	}
+
+	var b strings.Builder
+	explainBlock(&b, block, 1)
+
+	if want := "  rules  optional, nested list\n    port  required\n"; b.String() != want {
+		t.Fatalf("explainBlock() = %q, want %q", b.String(), want)
+	}
+}
+
+func TestRunSchemaDump(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, "")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"schema", "dump", "--root", root}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitClean, stderr.String())
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &decoded); err != nil {
+		t.Fatalf("schema dump should print JSON: %v", err)
+	}
+	if _, ok := decoded["provider_schemas"]; !ok {
+		t.Fatalf("schema dump should contain provider_schemas, got %v", decoded)
+	}
+
+	if code := run([]string{"schema"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("schema without subcommand should fail, got %d", code)
+	}
+}
+
+func installFakeTerraform(t *testing.T) {
+	t.Helper()
+	installFakeTerraformSchema(t, fakeSchema)
+}
+
+func installFakeTerraformSchema(t *testing.T, schema string) {
+	t.Helper()
+	t.Setenv("TERRAFORM_ROOT", "")
+
+	helperDir := t.TempDir()
+	script := filepath.Join(helperDir, "terraform")
+	content := `#!/bin/sh
+if [ "$1" = "init" ]; then
+  exit 0
+fi
+if [ "$1" = "providers" ] && [ "$2" = "schema" ]; then
+  cat <<'JSON'
+` + schema + `
+JSON
+  exit 0
+fi
+echo "unexpected args: $@" >&2
+exit 1
+`
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatalf("failed to write fake terraform: %v", err)
+	}
+	t.Setenv("PATH", helperDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func writeModule(t *testing.T, resources string) string {
+	t.Helper()
+	root := t.TempDir()
+	content := `terraform {
+  required_providers {
+    azurerm = {
+      source = "hashicorp/azurerm"
+    }
+  }
+}
+
+` + resources + "\n"
+	if err := os.WriteFile(filepath.Join(root, "main.tf"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write main.tf: %v", err)
+	}
+	return root
+}
+
+func TestRunSchemaDumpKeepsInitializedRoot(t *testing.T) {
+	installFakeTerraform(t)
+	root := writeModule(t, "")
+	for _, path := range []string{".terraform.lock.hcl", filepath.Join(".terraform", "terraform.tfstate")} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, path), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"schema", "dump", "--root", root}, &stdout, &stderr); code != exitClean {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitClean, stderr.String())
+	}
+
+	for _, path := range []string{".terraform.lock.hcl", ".terraform"} {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			t.Errorf("%s should survive a read-only command: %v", path, err)
+		}
+	}
+}
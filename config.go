@@ -2,6 +2,7 @@
 package diffy
 
 import (
+	"io"
 	"os"
 )
 
@@ -15,8 +16,19 @@ type SchemaValidatorOptions struct {
 	Silent              bool
 	ExcludedResources   []string
 	ExcludedDataSources []string
+	ExcludedEphemeral   []string
+	ExcludedAttributes  []string
+	Suppressions        []AttributeSuppression
 	Parser              HCLParser
 	TerraformRunner     TerraformRunner
+	Format              string
+	Output              io.Writer
+	OutputFile          string
+	GitHubActions       bool
+	BaselineFile        string
+	ConfigFile          string
+	IgnoredAttributes   map[string][]string
+	SeverityOverrides   map[string]Severity
 }
 
 type SchemaValidatorOption func(*SchemaValidatorOptions)
@@ -46,6 +58,32 @@ func WithExcludedDataSources(dataSources ...string) SchemaValidatorOption {
 	}
 }
 
+func WithExcludedEphemeralResources(ephemeralResources ...string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.ExcludedEphemeral = append(opts.ExcludedEphemeral, ephemeralResources...)
+	}
+}
+
+// WithExcludedAttributes skips attributes and blocks matching glob patterns like "*.tags" or "azurerm_*.timeouts".
+func WithExcludedAttributes(patterns ...string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.ExcludedAttributes = append(opts.ExcludedAttributes, patterns...)
+	}
+}
+
+// AttributeSuppression accepts findings matching an attribute pattern until its metadata expires.
+type AttributeSuppression struct {
+	Pattern string `json:"pattern"`
+	SuppressionMetadata
+}
+
+// WithAttributeSuppression behaves like WithExcludedAttributes until the expiry date, then reports the findings again.
+func WithAttributeSuppression(pattern string, metadata SuppressionMetadata) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.Suppressions = append(opts.Suppressions, AttributeSuppression{Pattern: pattern, SuppressionMetadata: metadata})
+	}
+}
+
 func WithParser(parser HCLParser) SchemaValidatorOption {
 	return func(opts *SchemaValidatorOptions) {
 		opts.Parser = parser
@@ -57,3 +95,61 @@ func WithTerraformRunner(runner TerraformRunner) SchemaValidatorOption {
 		opts.TerraformRunner = runner
 	}
 }
+
+func WithOutputFormat(format string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.Format = format
+	}
+}
+
+func WithOutput(w io.Writer) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.Output = w
+	}
+}
+
+func WithOutputFile(path string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.OutputFile = path
+	}
+}
+
+func WithGitHubActions() SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.GitHubActions = true
+	}
+}
+
+func WithBaseline(path string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.BaselineFile = path
+	}
+}
+
+// WithConfigFile loads project configuration from path instead of the .diffy.hcl at the Terraform root.
+func WithConfigFile(path string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		opts.ConfigFile = path
+	}
+}
+
+// WithIgnoredAttributes ignores findings for attribute or block paths of a resource type; prefix data sources with "data."
+// and ephemeral resources with "ephemeral.".
+func WithIgnoredAttributes(resourceType string, paths ...string) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		if opts.IgnoredAttributes == nil {
+			opts.IgnoredAttributes = make(map[string][]string)
+		}
+		opts.IgnoredAttributes[resourceType] = append(opts.IgnoredAttributes[resourceType], paths...)
+	}
+}
+
+// WithSeverityOverride reports findings of a rule with another severity; unknown rules and severities fail validation.
+func WithSeverityOverride(rule string, level Severity) SchemaValidatorOption {
+	return func(opts *SchemaValidatorOptions) {
+		if opts.SeverityOverrides == nil {
+			opts.SeverityOverrides = make(map[string]Severity)
+		}
+		opts.SeverityOverrides[rule] = level
+	}
+}
@@ -1,6 +1,7 @@
 package diffy
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -134,3 +135,23 @@ func TestOptionChaining(t *testing.T) {
 		t.Error("TerraformRunner not set correctly")
 	}
 }
+
+func TestWithOutputFormatAndOutput(t *testing.T) {
+	opts := &SchemaValidatorOptions{}
+	var out bytes.Buffer
+
+	WithOutputFormat("text")(opts)
+	WithOutput(&out)(opts)
+
+	if opts.Format != "text" {
+		t.Errorf("Format = %q, want %q", opts.Format, "text")
+	}
+	if opts.Output != &out {
+		t.Error("Output not set correctly")
+	}
+
+	WithOutputFile("report.json")(opts)
+	if opts.OutputFile != "report.json" {
+		t.Errorf("OutputFile = %q, want %q", opts.OutputFile, "report.json")
+	}
+}
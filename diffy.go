@@ -4,24 +4,148 @@ package diffy
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
 func ValidateSchema(options ...SchemaValidatorOption) ([]ValidationFinding, error) {
+	report, err := ValidateSchemaReport(options...)
+	if err != nil {
+		return nil, err
+	}
+	return report.Findings, nil
+}
+
+// ValidateSchemaReport runs the same validation as ValidateSchema and returns the full report,
+// so callers can tell submodules that could not be validated apart from clean ones.
+func ValidateSchemaReport(options ...SchemaValidatorOption) (*Report, error) {
+	opts, err := resolveOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	formatter, err := NewReportFormatter(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := buildReport(opts)
+	if err != nil {
+		return nil, err
+	}
+	findings := report.Findings
+
+	if err := writeReport(opts, formatter, report); err != nil {
+		return nil, err
+	}
+
+	if opts.GitHubActions {
+		if err := writeGitHubActionsOutput(opts, report); err != nil {
+			opts.Logger.Logf("Failed to write GitHub Actions output: %v", err)
+		}
+	}
+
+	if opts.CreateGitHubIssue {
+		ctx := context.Background()
+		if err := createGitHubIssue(ctx, opts, findings); err != nil {
+			opts.Logger.Logf("Failed to create/update GitHub issue: %v", err)
+		}
+	}
+
+	return report, nil
+}
+
+// CollectReport validates the Terraform root and applies the baseline without writing any output:
+// no report file, GitHub Actions annotations or step summary, and no GitHub issue.
+func CollectReport(options ...SchemaValidatorOption) (*Report, error) {
+	opts, err := resolveOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+	return buildReport(opts)
+}
+
+func buildReport(opts *SchemaValidatorOptions) (*Report, error) {
+	report, err := validateProject(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyBaseline(opts, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// LoadSchema initializes the Terraform root and returns the provider schemas it resolves to.
+func LoadSchema(options ...SchemaValidatorOption) (*TerraformSchema, error) {
+	opts, err := resolveOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	absRoot, err := filepath.Abs(opts.TerraformRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve absolute path for %s: %w", opts.TerraformRoot, err)
+	}
+
+	runner := opts.TerraformRunner
+	if runner == nil {
+		runner = NewTerraformRunner()
+	}
+
+	defer trackTerraformArtifacts(absRoot)()
+
+	ctx := context.Background()
+	if err := runner.Init(ctx, absRoot); err != nil {
+		return nil, err
+	}
+
+	return runner.GetSchema(ctx, absRoot)
+}
+
+func resolveOptions(options ...SchemaValidatorOption) (*SchemaValidatorOptions, error) {
 	opts := &SchemaValidatorOptions{
 		Logger:            &SimpleLogger{},
 		CreateGitHubIssue: false,
 		Silent:            false,
+		Format:            FormatText,
+		Output:            os.Stdout,
+	}
+
+	probe := &SchemaValidatorOptions{}
+	for _, option := range options {
+		option(probe)
+	}
+
+	root := probe.TerraformRoot
+	if envRoot := os.Getenv("TERRAFORM_ROOT"); envRoot != "" {
+		root = envRoot
+	}
+
+	config, configFile, err := resolveConfigFile(root, probe.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		opts.ConfigFile = configFile
+		config.Apply(opts)
 	}
 
 	for _, option := range options {
 		option(opts)
 	}
 
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		opts.GitHubActions = true
+	}
+
 	if envRoot := os.Getenv("TERRAFORM_ROOT"); envRoot != "" {
 		opts.TerraformRoot = envRoot
 	}
@@ -42,30 +166,42 @@ func ValidateSchema(options ...SchemaValidatorOption) ([]ValidationFinding, erro
 		opts.ExcludedDataSources = append(opts.ExcludedDataSources, dataSources...)
 	}
 
-	if opts.TerraformRoot == "" {
-		return nil, fmt.Errorf("terraform root path not specified - set TERRAFORM_ROOT environment variable or use WithTerraformRoot option")
+	if envExcludedEphemeral := os.Getenv("EXCLUDED_EPHEMERAL_RESOURCES"); envExcludedEphemeral != "" {
+		ephemeralResources := strings.Split(envExcludedEphemeral, ",")
+		for i, er := range ephemeralResources {
+			ephemeralResources[i] = strings.TrimSpace(er)
+		}
+		opts.ExcludedEphemeral = append(opts.ExcludedEphemeral, ephemeralResources...)
 	}
 
-	findings, err := validateProject(opts)
-	if err != nil {
-		return nil, err
+	if envExcludedAttributes := os.Getenv("EXCLUDED_ATTRIBUTES"); envExcludedAttributes != "" {
+		patterns := strings.Split(envExcludedAttributes, ",")
+		for i, p := range patterns {
+			patterns[i] = strings.TrimSpace(p)
+		}
+		opts.ExcludedAttributes = append(opts.ExcludedAttributes, patterns...)
 	}
 
-	if !opts.Silent {
-		outputFindings(findings)
+	for _, rule := range slices.Sorted(maps.Keys(opts.SeverityOverrides)) {
+		if err := validateSeverityOverride(rule, opts.SeverityOverrides[rule]); err != nil {
+			return nil, err
+		}
 	}
 
-	if opts.CreateGitHubIssue {
-		ctx := context.Background()
-		if err := createGitHubIssue(ctx, opts, findings); err != nil {
-			opts.Logger.Logf("Failed to create/update GitHub issue: %v", err)
-		}
+	if opts.TerraformRoot == "" {
+		return nil, fmt.Errorf("terraform root path not specified - set TERRAFORM_ROOT environment variable or use WithTerraformRoot option")
 	}
 
-	return findings, nil
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+
+	return opts, nil
 }
 
-func validateProject(opts *SchemaValidatorOptions) ([]ValidationFinding, error) {
+func validateProject(opts *SchemaValidatorOptions) (*Report, error) {
+	started := time.Now()
+
 	absRoot, err := filepath.Abs(opts.TerraformRoot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve absolute path for %s: %w", opts.TerraformRoot, err)
@@ -81,94 +217,100 @@ func validateProject(opts *SchemaValidatorOptions) ([]ValidationFinding, error)
 		runner = NewTerraformRunner()
 	}
 
-	rootFindings, err := ValidateTerraformSchemaWithOptions(
-		opts.Logger,
-		absRoot,
-		"",
-		parser,
-		runner,
-		opts.ExcludedResources,
-		opts.ExcludedDataSources,
-	)
+	defer trackTerraformArtifacts(absRoot)()
+
+	rootModule, err := validateModule(opts, parser, runner, absRoot, "")
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	var allFindings []ValidationFinding
-	allFindings = append(allFindings, rootFindings...)
+	modules := []ModuleReport{*rootModule}
 
 	modulesDir := filepath.Join(absRoot, "modules")
 	submodules, err := FindSubmodules(modulesDir)
 	if err != nil {
 		if !opts.Silent {
-			fmt.Printf("Note: No submodules found in %s\n", modulesDir)
+			fmt.Fprintf(opts.Output, "Note: No submodules found in %s\n", modulesDir)
 		}
 	} else if len(submodules) > 0 {
 		concurrency := max(runtime.NumCPU(), 1)
 
-		type moduleResult struct {
-			findings []ValidationFinding
-		}
-
-		results := make(chan moduleResult, len(submodules))
+		results := make([]ModuleReport, len(submodules))
 		sem := make(chan struct{}, concurrency)
 		var wg sync.WaitGroup
 
-		for _, module := range submodules {
+		for i, module := range submodules {
 			wg.Add(1)
-			go func(sm SubModule) {
+			go func(i int, sm SubModule) {
 				defer wg.Done()
 				sem <- struct{}{}
 				defer func() { <-sem }()
+				defer trackTerraformArtifacts(sm.Path)()
 
-				findings, err := ValidateTerraformSchemaWithOptions(
-					opts.Logger,
-					sm.Path,
-					sm.Name,
-					parser,
-					runner,
-					opts.ExcludedResources,
-					opts.ExcludedDataSources,
-				)
+				moduleStarted := time.Now()
+				result, err := validateModule(opts, parser, runner, sm.Path, sm.Name)
 				if err != nil {
 					opts.Logger.Logf("Failed to validate submodule %s: %v", sm.Name, err)
+					results[i] = ModuleReport{
+						Name:     sm.Name,
+						Path:     sm.Path,
+						Status:   ModuleStatusError,
+						Error:    err.Error(),
+						Duration: time.Since(moduleStarted),
+					}
 					return
 				}
 
-				results <- moduleResult{findings: findings}
-			}(module)
+				results[i] = *result
+			}(i, module)
 		}
 
 		wg.Wait()
-		close(results)
 
-		for res := range results {
-			allFindings = append(allFindings, res.findings...)
-		}
+		modules = append(modules, results...)
+	}
 
-		for _, sm := range submodules {
-			cleanupTerraformArtifacts(sm.Path)
-		}
+	report := &Report{
+		Root:      absRoot,
+		StartedAt: started,
+		Options:   newReportOptions(opts),
+		Modules:   modules,
 	}
 
-	cleanupTerraformArtifacts(absRoot)
+	var allFindings []ValidationFinding
+	for _, module := range modules {
+		allFindings = append(allFindings, module.Findings...)
+		report.Skipped = append(report.Skipped, module.Skipped...)
+	}
 
-	deduplicatedFindings := DeduplicateFindings(allFindings)
+	report.Findings = DeduplicateFindings(allFindings)
+	report.Duration = time.Since(started)
 
-	return deduplicatedFindings, nil
+	return report, nil
 }
 
-func outputFindings(findings []ValidationFinding) {
-	if len(findings) == 0 {
-		fmt.Println("No validation findings.")
-		return
+func writeReport(opts *SchemaValidatorOptions, formatter ReportFormatter, report *Report) error {
+	if opts.OutputFile != "" {
+		f, err := os.Create(opts.OutputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create report file %s: %w", opts.OutputFile, err)
+		}
+		defer f.Close()
+
+		if err := formatter.Format(f, report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		return f.Close()
 	}
 
-	fmt.Printf("Found %d issues:\n", len(findings))
+	if opts.Silent {
+		return nil
+	}
 
-	for _, finding := range findings {
-		fmt.Println(FormatFinding(finding))
+	if err := formatter.Format(opts.Output, report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
 	}
+	return nil
 }
 
 func createGitHubIssue(ctx context.Context, opts *SchemaValidatorOptions, findings []ValidationFinding) error {
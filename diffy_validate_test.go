@@ -174,3 +174,79 @@ func (f *failingRunner) Init(_ context.Context, _ string) error {
 func (f *failingRunner) GetSchema(_ context.Context, _ string) (*TerraformSchema, error) {
 	return nil, fmt.Errorf("should not be called")
 }
+
+func TestValidateSchemaRejectsUnknownFormat(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.tf"), "# test")
+
+	_, err := ValidateSchema(
+		WithTerraformRoot(root),
+		WithOutputFormat("yaml"),
+		WithParser(&validateStubParser{providerSource: "registry.terraform.io/hashicorp/azurerm"}),
+		WithTerraformRunner(&validateStubRunner{schema: &TerraformSchema{}}),
+	)
+	if err == nil || !strings.Contains(err.Error(), "unsupported output format") {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
+
+func TestValidateSchemaReportRejectsInvalidSeverityOverride(t *testing.T) {
+	t.Setenv("TERRAFORM_ROOT", "")
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.tf"), "# test")
+
+	tests := []struct {
+		name    string
+		rule    string
+		level   Severity
+		wantErr string
+	}{
+		{name: "unknown rule", rule: "missing-required-atribute", level: SeverityNote, wantErr: `unknown rule "missing-required-atribute"`},
+		{name: "invalid level", rule: RuleUnknownAttribute, level: "fatal", wantErr: `invalid severity "fatal"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateSchemaReport(
+				WithTerraformRoot(root),
+				WithSeverityOverride(tt.rule, tt.level),
+				WithParser(&validateStubParser{providerSource: "registry.terraform.io/hashicorp/azurerm"}),
+				WithTerraformRunner(&validateStubRunner{schema: &TerraformSchema{}}),
+			)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadSchemaUsesRunner(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("TERRAFORM_ROOT", "")
+
+	runner := &validateStubRunner{
+		schema: &TerraformSchema{
+			ProviderSchemas: map[string]*ProviderSchema{
+				"registry.terraform.io/hashicorp/azurerm": {
+					ResourceSchemas: map[string]*ResourceSchema{
+						"azurerm_resource_group": {Block: &SchemaBlock{}},
+					},
+				},
+			},
+		},
+	}
+
+	schema, err := LoadSchema(WithTerraformRoot(root), WithTerraformRunner(runner))
+	if err != nil {
+		t.Fatalf("LoadSchema returned error: %v", err)
+	}
+
+	source, resSchema, ok := FindResourceSchema(schema, "azurerm_resource_group", false)
+	if !ok || resSchema == nil || source != "registry.terraform.io/hashicorp/azurerm" {
+		t.Fatalf("FindResourceSchema() = %q, %v, %v", source, resSchema, ok)
+	}
+
+	if _, _, ok := FindResourceSchema(schema, "azurerm_resource_group", true); ok {
+		t.Fatalf("resource type should not resolve as a data source")
+	}
+}
@@ -0,0 +1,83 @@
+package diffy
+
+// EntityKind distinguishes the top-level Terraform blocks diffy validates against provider schemas.
+type EntityKind string
+
+const (
+	EntityKindResource          EntityKind = "resource"
+	EntityKindDataSource        EntityKind = "data source"
+	EntityKindEphemeralResource EntityKind = "ephemeral resource"
+	EntityKindProvider          EntityKind = "provider"
+)
+
+func entityKindOf(isDataSource, isEphemeral, isProvider bool) EntityKind {
+	switch {
+	case isProvider:
+		return EntityKindProvider
+	case isEphemeral:
+		return EntityKindEphemeralResource
+	case isDataSource:
+		return EntityKindDataSource
+	default:
+		return EntityKindResource
+	}
+}
+
+// addressPrefix is the mode prefix Terraform puts in front of addresses of this kind.
+func (kind EntityKind) addressPrefix() string {
+	switch kind {
+	case EntityKindDataSource:
+		return "data."
+	case EntityKindEphemeralResource:
+		return "ephemeral."
+	case EntityKindProvider:
+		return "provider."
+	default:
+		return ""
+	}
+}
+
+// schema looks up the provider's schema for an entity; provider configurations have a single schema of their own.
+func (providerSchema *ProviderSchema) schema(kind EntityKind, entityType string) (*ResourceSchema, bool) {
+	var schemas map[string]*ResourceSchema
+	switch kind {
+	case EntityKindProvider:
+		return providerSchema.Provider, providerSchema.Provider != nil
+	case EntityKindDataSource:
+		schemas = providerSchema.DataSourceSchemas
+	case EntityKindEphemeralResource:
+		schemas = providerSchema.EphemeralResourceSchemas
+	default:
+		schemas = providerSchema.ResourceSchemas
+	}
+	resSchema, ok := schemas[entityType]
+	return resSchema, ok
+}
+
+// EntityKind reports whether the finding belongs to a resource, data source, ephemeral resource or provider.
+func (finding ValidationFinding) EntityKind() EntityKind {
+	return entityKindOf(finding.IsDataSource, finding.IsEphemeral, finding.IsProvider)
+}
+
+// EntityKind reports whether the entity is a resource, data source, ephemeral resource or provider.
+func (entity EntityReport) EntityKind() EntityKind {
+	return entityKindOf(entity.IsDataSource, entity.IsEphemeral, entity.IsProvider)
+}
+
+// EntityKind reports whether the skipped entity is a resource, data source, ephemeral resource or provider.
+func (skipped SkippedEntity) EntityKind() EntityKind {
+	return entityKindOf(skipped.IsDataSource, skipped.IsEphemeral, skipped.IsProvider)
+}
+
+// entityAddress builds the Terraform address of an entity, prefixed with its mode and submodule path.
+// Provider configurations are addressed by their local name, followed by the alias when there is one.
+func entityAddress(submoduleName, entityType, name string, kind EntityKind) string {
+	address := kind.addressPrefix() + entityType
+	if name != "" {
+		address += "." + name
+	}
+	if submoduleName != "" {
+		address = "module." + submoduleName + "." + address
+	}
+	return address
+}
@@ -0,0 +1,103 @@
+package diffy
+
+import "testing"
+
+func TestEntityAddress(t *testing.T) {
+	tests := []struct {
+		name          string
+		submoduleName string
+		entityType    string
+		entityName    string
+		kind          EntityKind
+		want          string
+	}{
+		{name: "resource", kind: EntityKindResource, want: "azurerm_key_vault_secret.password"},
+		{name: "data source", kind: EntityKindDataSource, want: "data.azurerm_key_vault_secret.password"},
+		{name: "ephemeral resource", kind: EntityKindEphemeralResource, want: "ephemeral.azurerm_key_vault_secret.password"},
+		{name: "submodule ephemeral resource", submoduleName: "vault", kind: EntityKindEphemeralResource, want: "module.vault.ephemeral.azurerm_key_vault_secret.password"},
+		{name: "default provider", entityType: "azurerm", kind: EntityKindProvider, want: "provider.azurerm"},
+		{name: "aliased provider", entityType: "azurerm", entityName: "secondary", kind: EntityKindProvider, want: "provider.azurerm.secondary"},
+		{name: "submodule provider", submoduleName: "vault", entityType: "azurerm", kind: EntityKindProvider, want: "module.vault.provider.azurerm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entityType, entityName := tt.entityType, tt.entityName
+			if entityType == "" {
+				entityType, entityName = "azurerm_key_vault_secret", "password"
+			}
+			if got := entityAddress(tt.submoduleName, entityType, entityName, tt.kind); got != tt.want {
+				t.Errorf("entityAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntityKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		finding ValidationFinding
+		want    EntityKind
+	}{
+		{name: "resource", finding: ValidationFinding{}, want: EntityKindResource},
+		{name: "data source", finding: ValidationFinding{IsDataSource: true}, want: EntityKindDataSource},
+		{name: "ephemeral resource", finding: ValidationFinding{IsEphemeral: true}, want: EntityKindEphemeralResource},
+		{name: "provider", finding: ValidationFinding{IsProvider: true}, want: EntityKindProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.finding.EntityKind(); got != tt.want {
+				t.Errorf("ValidationFinding.EntityKind() = %q, want %q", got, tt.want)
+			}
+			entity := EntityReport{IsDataSource: tt.finding.IsDataSource, IsEphemeral: tt.finding.IsEphemeral, IsProvider: tt.finding.IsProvider}
+			if got := entity.EntityKind(); got != tt.want {
+				t.Errorf("EntityReport.EntityKind() = %q, want %q", got, tt.want)
+			}
+			skipped := SkippedEntity{IsDataSource: tt.finding.IsDataSource, IsEphemeral: tt.finding.IsEphemeral, IsProvider: tt.finding.IsProvider}
+			if got := skipped.EntityKind(); got != tt.want {
+				t.Errorf("SkippedEntity.EntityKind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderSchemaSchema(t *testing.T) {
+	provider := &ResourceSchema{}
+	resource := &ResourceSchema{}
+	dataSource := &ResourceSchema{}
+	ephemeral := &ResourceSchema{}
+	providerSchema := &ProviderSchema{
+		Provider:                 provider,
+		ResourceSchemas:          map[string]*ResourceSchema{"azurerm_key_vault_secret": resource},
+		DataSourceSchemas:        map[string]*ResourceSchema{"azurerm_key_vault_secret": dataSource},
+		EphemeralResourceSchemas: map[string]*ResourceSchema{"azurerm_key_vault_secret": ephemeral},
+	}
+
+	tests := []struct {
+		kind       EntityKind
+		entityType string
+		want       *ResourceSchema
+	}{
+		{kind: EntityKindResource, entityType: "azurerm_key_vault_secret", want: resource},
+		{kind: EntityKindDataSource, entityType: "azurerm_key_vault_secret", want: dataSource},
+		{kind: EntityKindEphemeralResource, entityType: "azurerm_key_vault_secret", want: ephemeral},
+		{kind: EntityKindProvider, entityType: "azurerm", want: provider},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			got, ok := providerSchema.schema(tt.kind, tt.entityType)
+			if !ok || got != tt.want {
+				t.Errorf("schema(%q, %q) returned the wrong schema", tt.kind, tt.entityType)
+			}
+		})
+	}
+
+	if _, ok := providerSchema.schema(EntityKindResource, "azurerm_key_vault"); ok {
+		t.Errorf("schema() found a resource type the provider does not define")
+	}
+	if _, ok := (&ProviderSchema{}).schema(EntityKindProvider, "azurerm"); ok {
+		t.Errorf("schema() found a provider schema that was not decoded")
+	}
+}
@@ -0,0 +1,166 @@
+package diffy
+
+import (
+	"path"
+	"strings"
+)
+
+// AttributeExclusions matches attribute and block paths against glob patterns such as
+// "*.tags", "azurerm_*.timeouts" or "azurerm_key_vault.network_acls.ip_rules".
+type AttributeExclusions struct {
+	patterns []exclusionPattern
+}
+
+type exclusionPattern struct {
+	module    string
+	hasModule bool
+	kind      EntityKind
+	segments  []string
+}
+
+// NewAttributeExclusions parses patterns of the form [module.<name>.][data.|ephemeral.|provider.]<type>.<path...>.<name>,
+// where every segment is a glob and "**" spans any number of nested blocks; provider patterns use the local provider name as type.
+func NewAttributeExclusions(patterns ...string) AttributeExclusions {
+	var exclusions AttributeExclusions
+	for _, raw := range patterns {
+		segments := strings.Split(strings.ToLower(strings.TrimSpace(raw)), ".")
+		pattern := exclusionPattern{kind: EntityKindResource}
+
+		if len(segments) > 2 && segments[0] == "module" {
+			pattern.module = segments[1]
+			pattern.hasModule = true
+			segments = segments[2:]
+		}
+		if len(segments) > 1 && segments[0] == "data" {
+			pattern.kind = EntityKindDataSource
+			segments = segments[1:]
+		} else if len(segments) > 1 && segments[0] == "ephemeral" {
+			pattern.kind = EntityKindEphemeralResource
+			segments = segments[1:]
+		} else if len(segments) > 1 && segments[0] == "provider" {
+			pattern.kind = EntityKindProvider
+			segments = segments[1:]
+		}
+		if len(segments) < 2 || !validGlobs(segments) {
+			continue
+		}
+
+		pattern.segments = segments
+		exclusions.patterns = append(exclusions.patterns, pattern)
+	}
+	return exclusions
+}
+
+func validGlobs(segments []string) bool {
+	for _, segment := range segments {
+		if segment == "" {
+			return false
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Scope keeps the patterns that apply to one submodule ("" for the root) and entity kind.
+func (exclusions AttributeExclusions) Scope(submoduleName string, kind EntityKind) AttributeExclusions {
+	var scoped AttributeExclusions
+	for _, pattern := range exclusions.patterns {
+		if pattern.kind != kind {
+			continue
+		}
+		if pattern.hasModule {
+			if submoduleName == "" {
+				continue
+			}
+			if ok, _ := path.Match(pattern.module, strings.ToLower(submoduleName)); !ok {
+				continue
+			}
+		}
+		scoped.patterns = append(scoped.patterns, pattern)
+	}
+	return scoped
+}
+
+func (exclusions AttributeExclusions) IsEmpty() bool {
+	return len(exclusions.patterns) == 0
+}
+
+// Excludes reports whether the attribute or block name at a validation path ("root.network_acls[0]") is excluded.
+func (exclusions AttributeExclusions) Excludes(resourceType, blockPath, name string) bool {
+	if exclusions.IsEmpty() {
+		return false
+	}
+
+	target := []string{strings.ToLower(resourceType)}
+	for segment := range strings.SplitSeq(blockPath, ".") {
+		if segment == "root" || segment == "" {
+			continue
+		}
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			segment = segment[:i]
+		}
+		target = append(target, strings.ToLower(segment))
+	}
+	target = append(target, strings.ToLower(name))
+
+	for _, pattern := range exclusions.patterns {
+		if matchSegments(pattern.segments, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludesFinding reports whether the finding's attribute, or any block enclosing it, is excluded.
+func (exclusions AttributeExclusions) ExcludesFinding(finding ValidationFinding) bool {
+	blockPath := "root"
+	for segment := range strings.SplitSeq(strings.TrimPrefix(strings.TrimPrefix(finding.Path, "root"), "."), ".") {
+		if segment == "" {
+			continue
+		}
+		name := segment
+		if i := strings.IndexByte(name, '['); i >= 0 {
+			name = name[:i]
+		}
+		if exclusions.Excludes(finding.ResourceType, blockPath, name) {
+			return true
+		}
+		blockPath += "." + segment
+	}
+	return exclusions.Excludes(finding.ResourceType, blockPath, finding.Name)
+}
+
+func matchSegments(pattern, target []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(target); i++ {
+				if matchSegments(pattern[1:], target[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(target) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], target[0]); !ok {
+			return false
+		}
+		pattern, target = pattern[1:], target[1:]
+	}
+	return len(target) == 0
+}
+
+// ignoredAttributePatterns turns per-resource ignore lists into exclusion patterns.
+func ignoredAttributePatterns(ignored map[string][]string) []string {
+	var patterns []string
+	for resourceType, paths := range ignored {
+		for _, p := range paths {
+			patterns = append(patterns, resourceType+"."+p)
+		}
+	}
+	return patterns
+}
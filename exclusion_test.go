@@ -0,0 +1,130 @@
+package diffy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestAttributeExclusionsExcludes(t *testing.T) {
+	exclusions := NewAttributeExclusions(
+		"*.tags",
+		"azurerm_*.timeouts",
+		"azurerm_key_vault.network_acls.ip_rules",
+		"azurerm_storage_account.**.days",
+		"data.azurerm_key_vault.purge_protection_enabled",
+		"ephemeral.azurerm_key_vault_secret.key_vault_id",
+		"provider.azurerm.features.key_vault",
+		"module.net*.azurerm_subnet.delegation",
+		"not-a-pattern",
+		"azurerm_[.name",
+	)
+
+	tests := []struct {
+		name         string
+		submodule    string
+		isDataSource bool
+		isEphemeral  bool
+		isProvider   bool
+		resourceType string
+		path         string
+		attribute    string
+		want         bool
+	}{
+		{name: "any type", resourceType: "azurerm_resource_group", path: "root", attribute: "tags", want: true},
+		{name: "any type is top level only", resourceType: "azurerm_resource_group", path: "root.identity", attribute: "tags", want: false},
+		{name: "type glob", resourceType: "azurerm_linux_web_app", path: "root", attribute: "timeouts", want: true},
+		{name: "type glob mismatch", resourceType: "aws_instance", path: "root", attribute: "timeouts", want: false},
+		{name: "nested path", resourceType: "azurerm_key_vault", path: "root.network_acls", attribute: "ip_rules", want: true},
+		{name: "nested path with index", resourceType: "azurerm_key_vault", path: "root.network_acls[1]", attribute: "ip_rules", want: true},
+		{name: "nested path sibling", resourceType: "azurerm_key_vault", path: "root.network_acls", attribute: "bypass", want: false},
+		{name: "double star", resourceType: "azurerm_storage_account", path: "root.blob_properties.delete_retention_policy", attribute: "days", want: true},
+		{name: "double star matches zero segments", resourceType: "azurerm_storage_account", path: "root", attribute: "days", want: true},
+		{name: "case insensitive", resourceType: "azurerm_resource_group", path: "root", attribute: "Tags", want: true},
+		{name: "data source pattern", isDataSource: true, resourceType: "azurerm_key_vault", path: "root", attribute: "purge_protection_enabled", want: true},
+		{name: "data source pattern skips resources", resourceType: "azurerm_key_vault", path: "root", attribute: "purge_protection_enabled", want: false},
+		{name: "resource patterns skip data sources", isDataSource: true, resourceType: "azurerm_resource_group", path: "root", attribute: "tags", want: false},
+		{name: "ephemeral pattern", isEphemeral: true, resourceType: "azurerm_key_vault_secret", path: "root", attribute: "key_vault_id", want: true},
+		{name: "ephemeral pattern skips resources", resourceType: "azurerm_key_vault_secret", path: "root", attribute: "key_vault_id", want: false},
+		{name: "resource patterns skip ephemeral resources", isEphemeral: true, resourceType: "azurerm_resource_group", path: "root", attribute: "tags", want: false},
+		{name: "provider pattern", isProvider: true, resourceType: "azurerm", path: "root.features", attribute: "key_vault", want: true},
+		{name: "provider pattern skips resources", resourceType: "azurerm", path: "root.features", attribute: "key_vault", want: false},
+		{name: "resource patterns skip providers", isProvider: true, resourceType: "azurerm_resource_group", path: "root", attribute: "tags", want: false},
+		{name: "module pattern", submodule: "network", resourceType: "azurerm_subnet", path: "root", attribute: "delegation", want: true},
+		{name: "module pattern other module", submodule: "storage", resourceType: "azurerm_subnet", path: "root", attribute: "delegation", want: false},
+		{name: "module pattern skips root", resourceType: "azurerm_subnet", path: "root", attribute: "delegation", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scoped := exclusions.Scope(tt.submodule, entityKindOf(tt.isDataSource, tt.isEphemeral, tt.isProvider))
+			if got := scoped.Excludes(tt.resourceType, tt.path, tt.attribute); got != tt.want {
+				t.Errorf("Excludes(%q, %q, %q) = %v, want %v", tt.resourceType, tt.path, tt.attribute, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAttributeExclusionsSkipsInvalidPatterns(t *testing.T) {
+	exclusions := NewAttributeExclusions("tags", "azurerm_[.name", "module.x.tags", "", "*..tags")
+	if !exclusions.IsEmpty() {
+		t.Fatalf("invalid patterns should be dropped, got %+v", exclusions)
+	}
+}
+
+func TestValidateWithExclusionsSkipsNestedBlocks(t *testing.T) {
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name": {Required: true},
+			"tags": {Optional: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"network_acls": {
+				Nesting: "single",
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{
+						"bypass":   {Required: true},
+						"ip_rules": {Optional: true},
+					},
+				},
+			},
+			"contact": {Nesting: "list"},
+		},
+	}
+
+	acls := NewBlockData()
+	data := NewBlockData()
+	data.StaticBlocks["network_acls"] = []*ParsedBlock{{Data: acls}}
+
+	var findings []ValidationFinding
+	exclusions := NewAttributeExclusions("*.tags", "azurerm_key_vault.network_acls.ip_rules", "azurerm_key_vault.contact")
+	data.ValidateWithExclusions("azurerm_key_vault", "root", schema, nil, exclusions, &findings)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Path+"."+f.Name)
+	}
+	want := []string{"root.name", "root.network_acls.bypass"}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIgnoredAttributePatterns(t *testing.T) {
+	patterns := ignoredAttributePatterns(map[string][]string{
+		"azurerm_storage_account": {"network_rules"},
+		"data.azurerm_key_vault":  {"tags"},
+	})
+	exclusions := NewAttributeExclusions(patterns...)
+
+	if !exclusions.Scope("", EntityKindResource).Excludes("azurerm_storage_account", "root", "network_rules") {
+		t.Error("per-resource ignores should exclude the named block")
+	}
+	if !exclusions.Scope("storage", EntityKindDataSource).Excludes("azurerm_key_vault", "root", "tags") {
+		t.Error("data source ignores should apply to data sources in every module")
+	}
+	if exclusions.Scope("", EntityKindResource).Excludes("azurerm_key_vault", "root", "tags") {
+		t.Error("data source ignores should not apply to resources")
+	}
+}
@@ -0,0 +1,249 @@
+package diffy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// FindingKind classifies what a finding reports; the zero value is a missing attribute or block.
+type FindingKind string
+
+const (
+	FindingKindMissing            FindingKind = ""
+	FindingKindUnusedSuppression  FindingKind = "unused_suppression"
+	FindingKindExpiredSuppression FindingKind = "expired_suppression"
+	FindingKindDeprecated         FindingKind = "deprecated"
+	FindingKindUnknown            FindingKind = "unknown"
+	FindingKindComputed           FindingKind = "computed"
+	FindingKindCardinality        FindingKind = "cardinality"
+	FindingKindUnknownCardinality FindingKind = "unknown_cardinality"
+	FindingKindTypeMismatch       FindingKind = "type_mismatch"
+)
+
+const (
+	RuleMissingRequiredAttribute = "missing-required-attribute"
+	RuleMissingOptionalAttribute = "missing-optional-attribute"
+	RuleMissingRequiredBlock     = "missing-required-block"
+	RuleMissingOptionalBlock     = "missing-optional-block"
+	RuleUnusedSuppression        = "unused-suppression"
+	RuleExpiredSuppression       = "expired-suppression"
+	RuleDeprecatedAttribute      = "deprecated-attribute"
+	RuleDeprecatedBlock          = "deprecated-block"
+	RuleUnknownAttribute         = "unknown-attribute"
+	RuleUnknownBlock             = "unknown-block"
+	RuleComputedAttribute        = "computed-attribute"
+	RuleBlockCardinality         = "block-cardinality"
+	RuleUnknownBlockCardinality  = "unknown-block-cardinality"
+	RuleTypeMismatch             = "type-mismatch"
+)
+
+// FindingRule describes a class of findings for formats that publish rule metadata.
+type FindingRule struct {
+	ID          string
+	Name        string
+	Description string
+	Severity    Severity
+}
+
+var findingRules = []FindingRule{
+	{
+		ID:          RuleMissingRequiredAttribute,
+		Name:        "MissingRequiredAttribute",
+		Description: "A required attribute defined by the provider schema is not set.",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleMissingOptionalAttribute,
+		Name:        "MissingOptionalAttribute",
+		Description: "An optional attribute defined by the provider schema is not set.",
+		Severity:    SeverityWarning,
+	},
+	{
+		ID:          RuleMissingRequiredBlock,
+		Name:        "MissingRequiredBlock",
+		Description: "A nested block the provider schema requires at least once is not declared.",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleMissingOptionalBlock,
+		Name:        "MissingOptionalBlock",
+		Description: "An optional nested block defined by the provider schema is not declared.",
+		Severity:    SeverityWarning,
+	},
+	{
+		ID:          RuleUnusedSuppression,
+		Name:        "UnusedSuppression",
+		Description: "A diffy:ignore comment does not match any finding, or is malformed and cannot apply to one.",
+		Severity:    SeverityWarning,
+	},
+	{
+		ID:          RuleExpiredSuppression,
+		Name:        "ExpiredSuppression",
+		Description: "A suppression passed its expiry date, so the finding it accepted is reported again.",
+		Severity:    SeverityWarning,
+	},
+	{
+		ID:          RuleDeprecatedAttribute,
+		Name:        "DeprecatedAttribute",
+		Description: "An attribute the provider schema marks as deprecated is still set.",
+		Severity:    SeverityWarning,
+	},
+	{
+		ID:          RuleDeprecatedBlock,
+		Name:        "DeprecatedBlock",
+		Description: "A nested block the provider schema marks as deprecated is still declared.",
+		Severity:    SeverityWarning,
+	},
+	{
+		ID:          RuleUnknownAttribute,
+		Name:        "UnknownAttribute",
+		Description: "An attribute is set that the provider schema does not define, usually a typo.",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleUnknownBlock,
+		Name:        "UnknownBlock",
+		Description: "A nested block is declared that the provider schema does not define, usually a typo.",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleComputedAttribute,
+		Name:        "ComputedAttribute",
+		Description: "An attribute the provider computes and does not accept as input is set, which fails at plan time.",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleBlockCardinality,
+		Name:        "BlockCardinality",
+		Description: "A nested block is declared more or fewer times than its nesting mode and item limits allow.",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleUnknownBlockCardinality,
+		Name:        "UnknownBlockCardinality",
+		Description: "A dynamic block expands at plan time, so its item limits cannot be verified statically.",
+		Severity:    SeverityNote,
+	},
+	{
+		ID:          RuleTypeMismatch,
+		Name:        "TypeMismatch",
+		Description: "A constant value cannot be converted to the type the provider schema declares for the attribute.",
+		Severity:    SeverityError,
+	},
+}
+
+// Rules returns the catalog of rules findings can be reported under.
+func Rules() []FindingRule {
+	rules := make([]FindingRule, len(findingRules))
+	copy(rules, findingRules)
+	return rules
+}
+
+// FindRule looks up a rule by its identifier.
+func FindRule(id string) (FindingRule, bool) {
+	for _, rule := range findingRules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return FindingRule{}, false
+}
+
+// RuleID identifies the rule a finding violates.
+func (finding ValidationFinding) RuleID() string {
+	switch {
+	case finding.Kind == FindingKindUnusedSuppression:
+		return RuleUnusedSuppression
+	case finding.Kind == FindingKindExpiredSuppression:
+		return RuleExpiredSuppression
+	case finding.Kind == FindingKindDeprecated && finding.IsBlock:
+		return RuleDeprecatedBlock
+	case finding.Kind == FindingKindDeprecated:
+		return RuleDeprecatedAttribute
+	case finding.Kind == FindingKindUnknown && finding.IsBlock:
+		return RuleUnknownBlock
+	case finding.Kind == FindingKindUnknown:
+		return RuleUnknownAttribute
+	case finding.Kind == FindingKindComputed:
+		return RuleComputedAttribute
+	case finding.Kind == FindingKindCardinality:
+		return RuleBlockCardinality
+	case finding.Kind == FindingKindUnknownCardinality:
+		return RuleUnknownBlockCardinality
+	case finding.Kind == FindingKindTypeMismatch:
+		return RuleTypeMismatch
+	case finding.IsBlock && finding.Required:
+		return RuleMissingRequiredBlock
+	case finding.IsBlock:
+		return RuleMissingOptionalBlock
+	case finding.Required:
+		return RuleMissingRequiredAttribute
+	default:
+		return RuleMissingOptionalAttribute
+	}
+}
+
+// Level returns the severity a finding is reported with, honoring configured overrides.
+func (finding ValidationFinding) Level() Severity {
+	if finding.Severity != "" {
+		return finding.Severity
+	}
+	if rule, ok := FindRule(finding.RuleID()); ok {
+		return rule.Severity
+	}
+	return SeverityWarning
+}
+
+// overrideSeverity applies the severity configured for the finding's rule, if any.
+func (finding *ValidationFinding) overrideSeverity(overrides map[string]Severity) {
+	if level, ok := overrides[finding.RuleID()]; ok {
+		finding.Severity = level
+	}
+}
+
+// fails reports whether a finding fails validation; notes are reported but never fail a module or the run.
+func (finding ValidationFinding) fails() bool {
+	return finding.Level() != SeverityNote
+}
+
+// Explanation describes the attribute or block a finding refers to, as documented in the provider schema,
+// folded onto a single line and flagged when the provider marks the attribute as sensitive.
+func (finding ValidationFinding) Explanation() string {
+	description := strings.Join(strings.Fields(finding.Description), " ")
+	if !finding.Sensitive {
+		return description
+	}
+	if description == "" {
+		return "(sensitive)"
+	}
+	return "(sensitive) " + description
+}
+
+// isSuppressionFinding reports findings about suppressions themselves, which cannot be suppressed in turn.
+func (finding ValidationFinding) isSuppressionFinding() bool {
+	return finding.Kind == FindingKindUnusedSuppression || finding.Kind == FindingKindExpiredSuppression
+}
+
+// Fingerprint identifies a finding independently of its line numbers, so it survives unrelated edits.
+func (finding ValidationFinding) Fingerprint() string {
+	key := strings.Join([]string{
+		finding.RuleID(),
+		finding.SubmoduleName,
+		finding.ResourceType,
+		finding.Address,
+		strings.TrimPrefix(finding.Path, "root"),
+		finding.Name,
+	}, "|")
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package diffy
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestFindingRuleIDAndLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		finding   ValidationFinding
+		wantRule  string
+		wantLevel Severity
+	}{
+		{name: "required attribute", finding: ValidationFinding{Required: true}, wantRule: RuleMissingRequiredAttribute, wantLevel: SeverityError},
+		{name: "optional attribute", finding: ValidationFinding{}, wantRule: RuleMissingOptionalAttribute, wantLevel: SeverityWarning},
+		{name: "required block", finding: ValidationFinding{Required: true, IsBlock: true}, wantRule: RuleMissingRequiredBlock, wantLevel: SeverityError},
+		{name: "optional block", finding: ValidationFinding{IsBlock: true}, wantRule: RuleMissingOptionalBlock, wantLevel: SeverityWarning},
+		{name: "severity override", finding: ValidationFinding{Severity: SeverityNote}, wantRule: RuleMissingOptionalAttribute, wantLevel: SeverityNote},
+		{name: "unused suppression", finding: ValidationFinding{Kind: FindingKindUnusedSuppression, IsBlock: true}, wantRule: RuleUnusedSuppression, wantLevel: SeverityWarning},
+		{name: "deprecated attribute", finding: ValidationFinding{Kind: FindingKindDeprecated}, wantRule: RuleDeprecatedAttribute, wantLevel: SeverityWarning},
+		{name: "deprecated block", finding: ValidationFinding{Kind: FindingKindDeprecated, IsBlock: true}, wantRule: RuleDeprecatedBlock, wantLevel: SeverityWarning},
+		{name: "unknown attribute", finding: ValidationFinding{Kind: FindingKindUnknown}, wantRule: RuleUnknownAttribute, wantLevel: SeverityError},
+		{name: "unknown block", finding: ValidationFinding{Kind: FindingKindUnknown, IsBlock: true}, wantRule: RuleUnknownBlock, wantLevel: SeverityError},
+		{name: "computed attribute", finding: ValidationFinding{Kind: FindingKindComputed}, wantRule: RuleComputedAttribute, wantLevel: SeverityError},
+		{name: "block cardinality", finding: ValidationFinding{Kind: FindingKindCardinality, IsBlock: true}, wantRule: RuleBlockCardinality, wantLevel: SeverityError},
+		{name: "type mismatch", finding: ValidationFinding{Kind: FindingKindTypeMismatch}, wantRule: RuleTypeMismatch, wantLevel: SeverityError},
+		{name: "unknown block cardinality", finding: ValidationFinding{Kind: FindingKindUnknownCardinality, IsBlock: true, Required: true}, wantRule: RuleUnknownBlockCardinality, wantLevel: SeverityNote},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.finding.RuleID(); got != tt.wantRule {
+				t.Errorf("RuleID() = %q, want %q", got, tt.wantRule)
+			}
+			if got := tt.finding.Level(); got != tt.wantLevel {
+				t.Errorf("Level() = %q, want %q", got, tt.wantLevel)
+			}
+			if _, ok := FindRule(tt.wantRule); !ok {
+				t.Errorf("rule %q should be in the catalog", tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestFindingExplanation(t *testing.T) {
+	tests := []struct {
+		name    string
+		finding ValidationFinding
+		want    string
+	}{
+		{name: "undocumented", finding: ValidationFinding{}, want: ""},
+		{name: "description", finding: ValidationFinding{Description: "  The SKU\n of the  account. "}, want: "The SKU of the account."},
+		{name: "sensitive", finding: ValidationFinding{Description: "The access key.", Sensitive: true}, want: "(sensitive) The access key."},
+		{name: "sensitive without description", finding: ValidationFinding{Sensitive: true}, want: "(sensitive)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.finding.Explanation(); got != tt.want {
+				t.Errorf("Explanation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindingFingerprint(t *testing.T) {
+	base := ValidationFinding{
+		ResourceType: "azurerm_storage_account",
+		Address:      "azurerm_storage_account.sa",
+		Path:         "root.network_rules",
+		Name:         "bypass",
+		Range:        hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 10}},
+	}
+
+	shifted := base
+	shifted.Range.Start.Line = 42
+	if base.Fingerprint() != shifted.Fingerprint() {
+		t.Fatalf("fingerprint should not depend on line numbers")
+	}
+
+	other := base
+	other.Address = "azurerm_storage_account.other"
+	if base.Fingerprint() == other.Fingerprint() {
+		t.Fatalf("fingerprint should differ between resources")
+	}
+
+	required := base
+	required.Required = true
+	if base.Fingerprint() == required.Fingerprint() {
+		t.Fatalf("fingerprint should differ between rules")
+	}
+}
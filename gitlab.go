@@ -0,0 +1,66 @@
+package diffy
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type GitLabFormatter struct{}
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+func (f *GitLabFormatter) Format(w io.Writer, report *Report) error {
+	issues := make([]gitlabIssue, 0, len(report.Findings))
+
+	for _, finding := range report.Findings {
+		location := gitlabLocation{
+			Path:  displayPath(moduleMainFile(report, finding.SubmoduleName)),
+			Lines: gitlabLines{Begin: 1},
+		}
+		if finding.Range.Filename != "" {
+			location = gitlabLocation{
+				Path:  displayPath(finding.Range.Filename),
+				Lines: gitlabLines{Begin: finding.Range.Start.Line, End: finding.Range.End.Line},
+			}
+		}
+
+		issues = append(issues, gitlabIssue{
+			Description: FormatFinding(finding),
+			CheckName:   finding.RuleID(),
+			Fingerprint: finding.Fingerprint(),
+			Severity:    gitlabSeverity(finding.Level()),
+			Location:    location,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+func gitlabSeverity(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "major"
+	case SeverityNote:
+		return "info"
+	default:
+		return "minor"
+	}
+}
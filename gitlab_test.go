@@ -0,0 +1,95 @@
+package diffy
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestGitLabFormatter(t *testing.T) {
+	required := ValidationFinding{
+		ResourceType: "azurerm_key_vault",
+		Address:      "azurerm_key_vault.kv",
+		Path:         "root",
+		Name:         "tenant_id",
+		Required:     true,
+		Range: hcl.Range{
+			Filename: "main.tf",
+			Start:    hcl.Pos{Line: 4, Column: 1},
+			End:      hcl.Pos{Line: 12, Column: 2},
+		},
+	}
+	optional := ValidationFinding{ResourceType: "azurerm_key_vault", Address: "azurerm_key_vault.kv", Path: "root", Name: "tags"}
+	submodule := ValidationFinding{ResourceType: "azurerm_subnet", Address: "module.network.azurerm_subnet.this", Path: "root", Name: "tags", SubmoduleName: "network"}
+
+	report := &Report{
+		Root: "infra",
+		Modules: []ModuleReport{
+			{Path: "infra"},
+			{Name: "network", Path: filepath.Join("infra", "modules", "network")},
+		},
+		Findings: []ValidationFinding{required, optional, submodule},
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatGitLab, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	var issues []struct {
+		Description string `json:"description"`
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Severity    string `json:"severity"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+				End   int `json:"end"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &issues); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+
+	first := issues[0]
+	if first.CheckName != RuleMissingRequiredAttribute || first.Severity != "major" || first.Fingerprint != required.Fingerprint() {
+		t.Errorf("unexpected required issue: %+v", first)
+	}
+	if first.Location.Path != "main.tf" || first.Location.Lines.Begin != 4 || first.Location.Lines.End != 12 {
+		t.Errorf("unexpected location: %+v", first.Location)
+	}
+	if first.Description != FormatFinding(required) {
+		t.Errorf("unexpected description: %q", first.Description)
+	}
+
+	second := issues[1]
+	if second.Severity != "minor" {
+		t.Errorf("optional findings should have a lower severity, got %q", second.Severity)
+	}
+	if second.Location.Path != filepath.Join("infra", "main.tf") || second.Location.Lines.Begin != 1 {
+		t.Errorf("findings without a range should fall back to the root main.tf, got %+v", second.Location)
+	}
+
+	if third := issues[2]; third.Location.Path != filepath.Join("infra", "modules", "network", "main.tf") {
+		t.Errorf("submodule findings without a range should fall back to the submodule main.tf, got %+v", third.Location)
+	}
+}
+
+func TestGitLabFormatterEmptyReport(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatGitLab, &Report{}); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+	if got := out.String(); got != "[]\n" {
+		t.Fatalf("empty report should be an empty array, got %q", got)
+	}
+}
@@ -0,0 +1,268 @@
+package diffy
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	coverageCovered    = "covered"
+	coverageMissing    = "missing"
+	coverageIgnored    = "ignored"
+	coverageComputed   = "computed"
+	coverageDeprecated = "deprecated"
+	coverageAbsent     = "absent"
+)
+
+//go:embed html_report.tmpl
+var htmlReportTemplate string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"location": FormatLocation,
+}).Parse(htmlReportTemplate))
+
+// HTMLFormatter renders a self-contained page with the schema coverage of every validated resource.
+type HTMLFormatter struct{}
+
+type htmlReport struct {
+	Report   *Report
+	Modules  []htmlModule
+	Findings int
+	Entities int
+}
+
+type htmlModule struct {
+	Module   ModuleReport
+	Entities []htmlEntity
+}
+
+type htmlEntity struct {
+	Entity   EntityReport
+	Kind     string
+	Findings []string
+	Nodes    []coverageNode
+}
+
+// coverageScope carries what keeps an attribute or block out of validation while the coverage tree is built:
+// ignore_changes entries and the attribute exclusions and suppressions in effect for the entity.
+type coverageScope struct {
+	resourceType string
+	path         string
+	ignore       []string
+	exclusions   AttributeExclusions
+}
+
+func (scope coverageScope) child(name string, ignore []string) coverageScope {
+	scope.path += "." + name
+	scope.ignore = ignore
+	return scope
+}
+
+// hides reports whether validation skips the attribute or block, so a gap there produces no finding.
+func (scope coverageScope) hides(data *BlockData, name string, isBlock bool) bool {
+	return isIgnored(scope.ignore, name) ||
+		scope.exclusions.Excludes(scope.resourceType, scope.path, name) ||
+		(data != nil && data.suppresses(name, isBlock))
+}
+
+type coverageNode struct {
+	Name     string
+	IsBlock  bool
+	Required bool
+	Status   string
+	Detail   string
+	Children []coverageNode
+}
+
+func (f *HTMLFormatter) Format(w io.Writer, report *Report) error {
+	data := htmlReport{Report: report, Findings: len(report.Findings)}
+
+	findingsByAddress := make(map[string][]string)
+	for _, finding := range report.Findings {
+		message := FormatFinding(finding)
+		if explanation := finding.Explanation(); explanation != "" {
+			message += " - " + explanation
+		}
+		findingsByAddress[finding.Address] = append(findingsByAddress[finding.Address], message)
+	}
+
+	for _, module := range report.Modules {
+		hm := htmlModule{Module: module}
+		for _, entity := range module.Entities {
+			hm.Entities = append(hm.Entities, htmlEntity{
+				Entity:   entity,
+				Kind:     string(entity.EntityKind()),
+				Findings: findingsByAddress[entity.Address],
+				Nodes: buildCoverageTree(entity.Schema, &entity.Data, coverageScope{
+					resourceType: entity.ResourceType,
+					path:         "root",
+					ignore:       entity.Data.IgnoreChanges,
+					exclusions:   entity.Exclusions,
+				}),
+			})
+		}
+		data.Entities += len(hm.Entities)
+		data.Modules = append(data.Modules, hm)
+	}
+
+	return htmlTemplate.Execute(w, data)
+}
+
+// buildCoverageTree walks the schema the same way BlockData.Validate does and labels every attribute and block.
+func buildCoverageTree(schema *SchemaBlock, data *BlockData, scope coverageScope) []coverageNode {
+	if schema == nil {
+		return nil
+	}
+
+	var nodes []coverageNode
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Attributes)) {
+		if name == "id" {
+			continue
+		}
+		attribute := schema.Attributes[name]
+
+		node := coverageNode{Name: name, Required: attribute.Required, Status: coverageMissing}
+		switch {
+		case attribute.Computed && !attribute.Optional && !attribute.Required:
+			node.Status = coverageComputed
+		case attribute.Deprecated:
+			node.Status = coverageDeprecated
+		case data != nil && data.setsAttribute(name, attribute):
+			node.Status = coverageCovered
+		case scope.hides(data, name, false):
+			node.Status = coverageIgnored
+		case data == nil:
+			node.Status = coverageAbsent
+		}
+		if attribute.NestedType != nil {
+			node.Detail = attribute.NestedType.NestingMode
+			node.Children = nestedCoverageTree(name, attribute.NestedType, data, scope)
+		}
+		nodes = append(nodes, node)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.BlockTypes)) {
+		blockType := schema.BlockTypes[name]
+
+		node := coverageNode{
+			Name:     name,
+			IsBlock:  true,
+			Required: blockType.MinItems > 0,
+			Status:   coverageMissing,
+			Detail:   blockTypeDetail(blockType),
+		}
+
+		var instances []*ParsedBlock
+		if data != nil {
+			instances = append(instances, data.StaticBlocks[name]...)
+			if dynamic := data.DynamicBlocks[name]; dynamic != nil {
+				instances = append(instances, dynamic)
+			}
+		}
+
+		switch {
+		case blockType.Deprecated:
+			node.Status = coverageDeprecated
+		case len(instances) > 0:
+			node.Status = coverageCovered
+		case name == "timeouts" || scope.hides(data, name, true):
+			node.Status = coverageIgnored
+		case data == nil:
+			node.Status = coverageAbsent
+		}
+
+		if len(instances) == 0 {
+			node.Children = buildCoverageTree(blockType.Block, nil, scope.child(name, scope.ignore))
+		} else {
+			merged := mergedBlockData(instances)
+			node.Children = buildCoverageTree(blockType.Block, &merged, scope.child(name, append(slices.Clone(scope.ignore), merged.IgnoreChanges...)))
+		}
+		if scope.exclusions.Excludes(scope.resourceType, scope.path, name) {
+			ignoreCoverageGaps(node.Children)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// nestedCoverageTree labels the attributes of a nested attribute type, counting every object literal assigned to it.
+func nestedCoverageTree(name string, nested *SchemaNestedType, data *BlockData, scope coverageScope) []coverageNode {
+	schema := &SchemaBlock{Attributes: nested.Attributes}
+
+	var instances []*ParsedBlock
+	if data != nil {
+		if assigned := data.Attributes[name]; assigned != nil {
+			for _, object := range nestedObjects(name, nested, assigned.Expr) {
+				instances = append(instances, &ParsedBlock{Data: objectBlockData(object.object)})
+			}
+		}
+	}
+
+	scope = scope.child(name, scope.ignore)
+	if len(instances) == 0 {
+		return buildCoverageTree(schema, nil, scope)
+	}
+	merged := mergedBlockData(instances)
+	// Findings on nested attributes are reported on the owning block, so its inline suppressions apply to them.
+	merged.Suppressions = data.Suppressions
+	return buildCoverageTree(schema, &merged, scope)
+}
+
+// ignoreCoverageGaps marks everything below an excluded block as ignored, since validation does not descend into it.
+func ignoreCoverageGaps(nodes []coverageNode) {
+	for i := range nodes {
+		if nodes[i].Status == coverageMissing || nodes[i].Status == coverageAbsent {
+			nodes[i].Status = coverageIgnored
+		}
+		ignoreCoverageGaps(nodes[i].Children)
+	}
+}
+
+// mergedBlockData combines every instance of a block so an attribute counts as covered if any instance sets it.
+// Dynamic blocks are folded into the static ones, since both count as instances for coverage.
+func mergedBlockData(instances []*ParsedBlock) BlockData {
+	if len(instances) == 1 {
+		return instances[0].Data
+	}
+
+	merged := NewBlockData()
+	for _, instance := range instances {
+		for name := range instance.Data.Properties {
+			merged.Properties[name] = true
+		}
+		for name, attribute := range instance.Data.Attributes {
+			merged.Attributes[name] = attribute
+		}
+		for name, blocks := range instance.Data.StaticBlocks {
+			merged.StaticBlocks[name] = append(merged.StaticBlocks[name], blocks...)
+		}
+		for name, block := range instance.Data.DynamicBlocks {
+			merged.StaticBlocks[name] = append(merged.StaticBlocks[name], block)
+		}
+		merged.IgnoreChanges = append(merged.IgnoreChanges, instance.Data.IgnoreChanges...)
+		merged.Suppressions = append(merged.Suppressions, instance.Data.Suppressions...)
+	}
+	return merged
+}
+
+func blockTypeDetail(blockType *SchemaBlockType) string {
+	parts := []string{}
+	if blockType.Nesting != "" {
+		parts = append(parts, blockType.Nesting)
+	}
+	if blockType.MinItems > 0 {
+		parts = append(parts, fmt.Sprintf("min %d", blockType.MinItems))
+	}
+	if blockType.MaxItems > 0 {
+		parts = append(parts, fmt.Sprintf("max %d", blockType.MaxItems))
+	}
+	return strings.Join(parts, ", ")
+}
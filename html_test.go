@@ -0,0 +1,234 @@
+package diffy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuildCoverageTree(t *testing.T) {
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"id":       {Computed: true},
+			"name":     {Required: true},
+			"location": {Required: true},
+			"tags":     {Optional: true},
+			"etag":     {Computed: true},
+			"legacy":   {Optional: true, Deprecated: true},
+			"sku":      {Optional: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"subnet": {
+				Nesting:  "list",
+				MinItems: 1,
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{
+						"name":           {Required: true},
+						"address_prefix": {Optional: true},
+					},
+				},
+			},
+			"identity": {
+				Nesting:  "list",
+				MaxItems: 1,
+				Block: &SchemaBlock{
+					Attributes: map[string]*SchemaAttribute{"type": {Required: true}},
+				},
+			},
+		},
+	}
+
+	data := NewBlockData()
+	data.Properties["name"] = true
+	data.IgnoreChanges = []string{"tags"}
+	subnet := NewBlockData()
+	subnet.Properties["name"] = true
+	data.StaticBlocks["subnet"] = []*ParsedBlock{{Data: subnet}}
+
+	nodes := buildCoverageTree(schema, &data, coverageScope{path: "root", ignore: data.IgnoreChanges})
+
+	status := map[string]string{}
+	var walk func(prefix string, nodes []coverageNode)
+	walk = func(prefix string, nodes []coverageNode) {
+		for _, node := range nodes {
+			status[prefix+node.Name] = node.Status
+			walk(prefix+node.Name+".", node.Children)
+		}
+	}
+	walk("", nodes)
+
+	want := map[string]string{
+		"name":                  coverageCovered,
+		"location":              coverageMissing,
+		"tags":                  coverageIgnored,
+		"etag":                  coverageComputed,
+		"legacy":                coverageDeprecated,
+		"sku":                   coverageMissing,
+		"subnet":                coverageCovered,
+		"subnet.name":           coverageCovered,
+		"subnet.address_prefix": coverageMissing,
+		"identity":              coverageMissing,
+		"identity.type":         coverageAbsent,
+	}
+	for name, wantStatus := range want {
+		if status[name] != wantStatus {
+			t.Errorf("status[%s] = %q, want %q", name, status[name], wantStatus)
+		}
+	}
+	if _, ok := status["id"]; ok {
+		t.Errorf("id should not be part of the coverage tree")
+	}
+}
+
+func TestBuildCoverageTreeHonorsExclusionsAndSuppressions(t *testing.T) {
+	body := parseHCLBody(t, `
+network_acls {
+  default_action = "Deny"
+}
+`)
+	parsed := ParseSyntaxBody(body)
+	parsed.Data.Suppressions = []Suppression{{Name: "sku_name"}, {Name: "expired", SuppressionMetadata: SuppressionMetadata{Expires: "2000-01-01"}}}
+
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"tenant_id": {Required: true},
+			"sku_name":  {Required: true},
+			"tags":      {Optional: true},
+			"expired":   {Optional: true},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"network_acls": {Nesting: "list", Block: &SchemaBlock{
+				Attributes: map[string]*SchemaAttribute{
+					"default_action": {Required: true},
+					"ip_rules":       {Optional: true},
+				},
+			}},
+			"contact": {Nesting: "list", Block: &SchemaBlock{
+				Attributes: map[string]*SchemaAttribute{"email": {Required: true}},
+			}},
+		},
+	}
+
+	exclusions := NewAttributeExclusions("*.tags", "azurerm_key_vault.network_acls.ip_rules", "azurerm_key_vault.contact")
+	nodes := buildCoverageTree(schema, &parsed.Data, coverageScope{
+		resourceType: "azurerm_key_vault",
+		path:         "root",
+		exclusions:   exclusions.Scope("", EntityKindResource),
+	})
+
+	status := map[string]string{}
+	var walk func(prefix string, nodes []coverageNode)
+	walk = func(prefix string, nodes []coverageNode) {
+		for _, node := range nodes {
+			status[prefix+node.Name] = node.Status
+			walk(prefix+node.Name+".", node.Children)
+		}
+	}
+	walk("", nodes)
+
+	want := map[string]string{
+		"tenant_id":                   coverageMissing,
+		"sku_name":                    coverageIgnored,
+		"tags":                        coverageIgnored,
+		"expired":                     coverageMissing,
+		"network_acls":                coverageCovered,
+		"network_acls.default_action": coverageCovered,
+		"network_acls.ip_rules":       coverageIgnored,
+		"contact":                     coverageIgnored,
+		"contact.email":               coverageIgnored,
+	}
+	for name, wantStatus := range want {
+		if status[name] != wantStatus {
+			t.Errorf("status[%s] = %q, want %q", name, status[name], wantStatus)
+		}
+	}
+}
+
+func TestBuildCoverageTreeNestedAttributes(t *testing.T) {
+	body := parseHCLBody(t, `
+rules = [
+  { name = "http", port = 80 },
+  { name = "https" },
+]
+`)
+	parsed := ParseSyntaxBody(body)
+
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"rules": {Optional: true, NestedType: &SchemaNestedType{
+				NestingMode: "list",
+				Attributes: map[string]*SchemaAttribute{
+					"name":     {Required: true},
+					"port":     {Optional: true},
+					"protocol": {Optional: true},
+				},
+			}},
+		},
+	}
+
+	nodes := buildCoverageTree(schema, &parsed.Data, coverageScope{path: "root"})
+	if len(nodes) != 1 || nodes[0].Detail != "list" {
+		t.Fatalf("unexpected nodes %+v", nodes)
+	}
+
+	status := map[string]string{}
+	for _, child := range nodes[0].Children {
+		status[child.Name] = child.Status
+	}
+	want := map[string]string{"name": coverageCovered, "port": coverageCovered, "protocol": coverageMissing}
+	for name, wantStatus := range want {
+		if status[name] != wantStatus {
+			t.Errorf("status[%s] = %q, want %q", name, status[name], wantStatus)
+		}
+	}
+}
+
+func TestHTMLFormatter(t *testing.T) {
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"name":     {Required: true},
+			"location": {Required: true},
+		},
+	}
+	data := NewBlockData()
+	data.Properties["name"] = true
+
+	report := &Report{
+		Root: "/work/<infra>",
+		Modules: []ModuleReport{{
+			Status: ModuleStatusFailed,
+			Entities: []EntityReport{{
+				Address:      "azurerm_resource_group.rg",
+				ResourceType: "azurerm_resource_group",
+				Schema:       schema,
+				Data:         data,
+			}},
+		}},
+		Findings: []ValidationFinding{
+			{ResourceType: "azurerm_resource_group", Address: "azurerm_resource_group.rg", Path: "root", Name: "location", Required: true},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatHTML, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"azurerm_resource_group.rg",
+		"missing required property location",
+		`class="node required covered"`,
+		`class="node required missing"`,
+		`data-filter="optional"`,
+		"/work/&lt;infra&gt;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML report should contain %q", want)
+		}
+	}
+	if strings.Contains(got, "<infra>") {
+		t.Errorf("HTML report should escape user content")
+	}
+}
@@ -3,6 +3,7 @@ package diffy
 
 import (
 	"context"
+	"io"
 )
 
 type BlockProcessor interface {
@@ -20,6 +21,10 @@ type IssueManager interface {
 	CreateOrUpdateIssue(ctx context.Context, findings []ValidationFinding) error
 }
 
+type ReportFormatter interface {
+	Format(w io.Writer, report *Report) error
+}
+
 type Logger interface {
 	Logf(format string, args ...any)
 }
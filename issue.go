@@ -41,12 +41,14 @@ func (manager *GitHubIssueManager) CreateOrUpdateIssue(ctx context.Context, find
 	dedup := make(map[string]ValidationFinding)
 
 	for _, finding := range findings {
-		key := fmt.Sprintf("%s|%s|%s|%v|%v|%s",
+		key := fmt.Sprintf("%s|%s|%s|%s|%s|%v|%v|%s",
+			finding.Kind,
 			finding.ResourceType,
+			finding.Address,
 			strings.ReplaceAll(finding.Path, "root.", ""),
 			finding.Name,
 			finding.IsBlock,
-			finding.IsDataSource,
+			finding.EntityKind(),
 			finding.SubmoduleName,
 		)
 		dedup[key] = finding
@@ -56,28 +58,27 @@ func (manager *GitHubIssueManager) CreateOrUpdateIssue(ctx context.Context, find
 
 	for _, finding := range dedup {
 		cleanPath := strings.ReplaceAll(finding.Path, "root.", "")
-		status := "optional"
-		if finding.Required {
-			status = "required"
-		}
 
-		itemType := "property"
-		if finding.IsBlock {
-			itemType = "block"
-		}
+		entityType := finding.EntityKind()
 
-		entityType := "resource"
-		if finding.IsDataSource {
-			entityType = "data source"
+		suffix := ""
+		if loc := FormatLocation(finding.Range); loc != "" {
+			suffix = fmt.Sprintf(" at `%s`", loc)
+		}
+		if finding.Detail != "" {
+			suffix += " - " + finding.Detail
+		}
+		if explanation := finding.Explanation(); explanation != "" {
+			suffix += "\n> " + explanation
 		}
 
 		if finding.SubmoduleName == "" {
-			fmt.Fprintf(&newBody, "`%s`: missing %s %s `%s` in `%s` (%s)\n\n",
-				finding.ResourceType, status, itemType, finding.Name, cleanPath, entityType,
+			fmt.Fprintf(&newBody, "`%s`: %s `%s` in `%s` (%s)%s\n\n",
+				findingSubject(finding), findingSummary(finding), finding.Name, cleanPath, entityType, suffix,
 			)
 		} else {
-			fmt.Fprintf(&newBody, "`%s`: missing %s %s `%s` in `%s` in submodule `%s` (%s)\n\n",
-				finding.ResourceType, status, itemType, finding.Name, cleanPath, finding.SubmoduleName, entityType,
+			fmt.Fprintf(&newBody, "`%s`: %s `%s` in `%s` in submodule `%s` (%s)%s\n\n",
+				findingSubject(finding), findingSummary(finding), finding.Name, cleanPath, finding.SubmoduleName, entityType, suffix,
 			)
 		}
 	}
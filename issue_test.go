@@ -42,6 +42,8 @@ func TestCreateOrUpdateIssue_CreatesNew(t *testing.T) {
 	findings := []ValidationFinding{
 		{ResourceType: "r1", Path: "root.attr", Name: "foo", Required: true},
 		{ResourceType: "r1", Path: "root.attr", Name: "foo", Required: true}, // duplicate should be deduped
+		{ResourceType: "r1", Address: "r1.second", Path: "root.attr", Name: "foo", Required: true},
+		{ResourceType: "r1", Address: "r1.third", Path: "root", Name: "key", Required: true, Description: "The access key.", Sensitive: true},
 	}
 
 	if err := manager.CreateOrUpdateIssue(context.Background(), findings); err != nil {
@@ -56,6 +58,12 @@ func TestCreateOrUpdateIssue_CreatesNew(t *testing.T) {
 	if !strings.Contains(body, "r1") || !strings.Contains(body, "`foo`") {
 		t.Fatalf("issue body missing content: %q", body)
 	}
+	if !strings.Contains(body, "r1.second") {
+		t.Fatalf("issue body should include the resource address: %q", body)
+	}
+	if !strings.Contains(body, `\n\u003e (sensitive) The access key.`) {
+		t.Fatalf("issue body should explain the attribute: %q", body)
+	}
 }
 
 func TestCreateOrUpdateIssue_UpdatesExisting(t *testing.T) {
@@ -0,0 +1,220 @@
+package diffy
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// ReportSchemaVersion identifies the layout of the JSON report and is bumped on breaking changes.
+const ReportSchemaVersion = "1"
+
+type JSONFormatter struct{}
+
+type jsonReport struct {
+	SchemaVersion string            `json:"schema_version"`
+	Root          string            `json:"root"`
+	StartedAt     time.Time         `json:"started_at"`
+	DurationMs    int64             `json:"duration_ms"`
+	Options       jsonReportOptions `json:"options"`
+	Summary       jsonSummary       `json:"summary"`
+	Modules       []jsonModule      `json:"modules"`
+	Findings      []jsonFinding     `json:"findings"`
+	Skipped       []jsonSkipped     `json:"skipped"`
+	Baseline      *jsonBaseline     `json:"baseline,omitempty"`
+}
+
+type jsonBaseline struct {
+	File    string          `json:"file"`
+	Matched int             `json:"matched"`
+	Fixed   []BaselineEntry `json:"fixed"`
+	Expired []BaselineEntry `json:"expired"`
+}
+
+type jsonReportOptions struct {
+	TerraformRoot       string                 `json:"terraform_root"`
+	ExcludedResources   []string               `json:"excluded_resources"`
+	ExcludedDataSources []string               `json:"excluded_data_sources"`
+	ExcludedEphemeral   []string               `json:"excluded_ephemeral_resources"`
+	ExcludedAttributes  []string               `json:"excluded_attributes"`
+	Suppressions        []AttributeSuppression `json:"suppressions,omitempty"`
+	CreateGitHubIssue   bool                   `json:"create_github_issue"`
+	GitHubOwner         string                 `json:"github_owner,omitempty"`
+	GitHubRepo          string                 `json:"github_repo,omitempty"`
+	Format              string                 `json:"format"`
+	OutputFile          string                 `json:"output_file,omitempty"`
+	BaselineFile        string                 `json:"baseline_file,omitempty"`
+	ConfigFile          string                 `json:"config_file,omitempty"`
+	SeverityOverrides   map[string]Severity    `json:"severity_overrides,omitempty"`
+}
+
+type jsonSummary struct {
+	Modules  int `json:"modules"`
+	Entities int `json:"entities"`
+	Findings int `json:"findings"`
+	Skipped  int `json:"skipped"`
+}
+
+type jsonModule struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Entities   int    `json:"entities"`
+	Findings   int    `json:"findings"`
+}
+
+type jsonFinding struct {
+	Rule         string        `json:"rule"`
+	Severity     string        `json:"severity"`
+	Fingerprint  string        `json:"fingerprint"`
+	Address      string        `json:"address"`
+	ResourceType string        `json:"resource_type"`
+	DataSource   bool          `json:"data_source"`
+	Ephemeral    bool          `json:"ephemeral"`
+	Provider     bool          `json:"provider"`
+	Submodule    string        `json:"submodule,omitempty"`
+	Path         string        `json:"path"`
+	Name         string        `json:"name"`
+	Block        bool          `json:"block"`
+	Required     bool          `json:"required"`
+	Message      string        `json:"message"`
+	Detail       string        `json:"detail,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	Sensitive    bool          `json:"sensitive,omitempty"`
+	Location     *jsonLocation `json:"location,omitempty"`
+}
+
+type jsonLocation struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_column"`
+	EndLine     int    `json:"end_line"`
+	EndColumn   int    `json:"end_column"`
+}
+
+type jsonSkipped struct {
+	Address      string `json:"address"`
+	ResourceType string `json:"resource_type"`
+	DataSource   bool   `json:"data_source"`
+	Ephemeral    bool   `json:"ephemeral"`
+	Provider     bool   `json:"provider"`
+	Submodule    string `json:"submodule,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+func (f *JSONFormatter) Format(w io.Writer, report *Report) error {
+	out := jsonReport{
+		SchemaVersion: ReportSchemaVersion,
+		Root:          report.Root,
+		StartedAt:     report.StartedAt,
+		DurationMs:    report.Duration.Milliseconds(),
+		Options: jsonReportOptions{
+			TerraformRoot:       report.Options.TerraformRoot,
+			ExcludedResources:   nonNil(report.Options.ExcludedResources),
+			ExcludedDataSources: nonNil(report.Options.ExcludedDataSources),
+			ExcludedEphemeral:   nonNil(report.Options.ExcludedEphemeral),
+			ExcludedAttributes:  nonNil(report.Options.ExcludedAttributes),
+			Suppressions:        report.Options.Suppressions,
+			CreateGitHubIssue:   report.Options.CreateGitHubIssue,
+			GitHubOwner:         report.Options.GitHubOwner,
+			GitHubRepo:          report.Options.GitHubRepo,
+			Format:              report.Options.Format,
+			OutputFile:          report.Options.OutputFile,
+			BaselineFile:        report.Options.BaselineFile,
+			ConfigFile:          report.Options.ConfigFile,
+			SeverityOverrides:   report.Options.SeverityOverrides,
+		},
+		Modules:  []jsonModule{},
+		Findings: []jsonFinding{},
+		Skipped:  []jsonSkipped{},
+	}
+
+	for _, module := range report.Modules {
+		out.Modules = append(out.Modules, jsonModule{
+			Name:       module.DisplayName(),
+			Path:       module.Path,
+			Status:     module.Status,
+			Error:      module.Error,
+			DurationMs: module.Duration.Milliseconds(),
+			Entities:   len(module.Entities),
+			Findings:   len(module.Findings),
+		})
+		out.Summary.Entities += len(module.Entities)
+	}
+
+	for _, finding := range report.Findings {
+		out.Findings = append(out.Findings, jsonFinding{
+			Rule:         finding.RuleID(),
+			Severity:     string(finding.Level()),
+			Fingerprint:  finding.Fingerprint(),
+			Address:      finding.Address,
+			ResourceType: finding.ResourceType,
+			DataSource:   finding.IsDataSource,
+			Ephemeral:    finding.IsEphemeral,
+			Provider:     finding.IsProvider,
+			Submodule:    finding.SubmoduleName,
+			Path:         finding.Path,
+			Name:         finding.Name,
+			Block:        finding.IsBlock,
+			Required:     finding.Required,
+			Message:      FormatFinding(finding),
+			Detail:       finding.Detail,
+			Description:  finding.Description,
+			Sensitive:    finding.Sensitive,
+			Location:     newJSONLocation(finding.Range),
+		})
+	}
+
+	for _, skipped := range report.Skipped {
+		out.Skipped = append(out.Skipped, jsonSkipped{
+			Address:      skipped.Address,
+			ResourceType: skipped.ResourceType,
+			DataSource:   skipped.IsDataSource,
+			Ephemeral:    skipped.IsEphemeral,
+			Provider:     skipped.IsProvider,
+			Submodule:    skipped.SubmoduleName,
+			Reason:       skipped.Reason,
+		})
+	}
+
+	if baseline := report.Baseline; baseline != nil {
+		out.Baseline = &jsonBaseline{
+			File:    baseline.File,
+			Matched: len(baseline.Matched),
+			Fixed:   nonNil(baseline.Fixed),
+			Expired: nonNil(baseline.Expired),
+		}
+	}
+
+	out.Summary.Modules = len(out.Modules)
+	out.Summary.Findings = len(out.Findings)
+	out.Summary.Skipped = len(out.Skipped)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+func newJSONLocation(r hcl.Range) *jsonLocation {
+	if r.Filename == "" {
+		return nil
+	}
+	return &jsonLocation{
+		File:        displayPath(r.Filename),
+		StartLine:   r.Start.Line,
+		StartColumn: r.Start.Column,
+		EndLine:     r.End.Line,
+		EndColumn:   r.End.Column,
+	}
+}
+
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
@@ -0,0 +1,195 @@
+package diffy
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	report := &Report{
+		Root:      "/work/infra",
+		StartedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration:  1500 * time.Millisecond,
+		Options: ReportOptions{
+			TerraformRoot:     "/work/infra",
+			ExcludedResources: []string{"azurerm_role_assignment"},
+			Format:            FormatJSON,
+		},
+		Modules: []ModuleReport{
+			{
+				Path:     "/work/infra",
+				Status:   ModuleStatusFailed,
+				Duration: time.Second,
+				Entities: []EntityReport{{Address: "azurerm_resource_group.rg"}},
+				Findings: []ValidationFinding{{Name: "location"}},
+			},
+			{Name: "network", Path: "/work/infra/modules/network", Status: ModuleStatusError, Error: "init failed"},
+		},
+		Findings: []ValidationFinding{
+			{
+				ResourceType: "azurerm_resource_group",
+				Address:      "azurerm_resource_group.rg",
+				Path:         "root",
+				Name:         "location",
+				Required:     true,
+				Description:  "The Azure region the resource group lives in.",
+				Range: hcl.Range{
+					Filename: "main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 1},
+					End:      hcl.Pos{Line: 5, Column: 2},
+				},
+			},
+		},
+		Skipped: []SkippedEntity{
+			{Address: "azurerm_role_assignment.ra", ResourceType: "azurerm_role_assignment", Reason: SkipReasonExcluded},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatJSON, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	var decoded struct {
+		SchemaVersion string `json:"schema_version"`
+		DurationMs    int64  `json:"duration_ms"`
+		Options       struct {
+			ExcludedResources   []string `json:"excluded_resources"`
+			ExcludedDataSources []string `json:"excluded_data_sources"`
+		} `json:"options"`
+		Summary struct {
+			Modules  int `json:"modules"`
+			Entities int `json:"entities"`
+			Findings int `json:"findings"`
+			Skipped  int `json:"skipped"`
+		} `json:"summary"`
+		Modules []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"modules"`
+		Findings []struct {
+			Address     string `json:"address"`
+			Required    bool   `json:"required"`
+			Message     string `json:"message"`
+			Description string `json:"description"`
+			Sensitive   bool   `json:"sensitive"`
+			Location    struct {
+				File      string `json:"file"`
+				StartLine int    `json:"start_line"`
+			} `json:"location"`
+		} `json:"findings"`
+		Skipped []struct {
+			Reason string `json:"reason"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+
+	if decoded.SchemaVersion != ReportSchemaVersion || decoded.DurationMs != 1500 {
+		t.Errorf("unexpected header: %+v", decoded)
+	}
+	if decoded.Options.ExcludedDataSources == nil || len(decoded.Options.ExcludedResources) != 1 {
+		t.Errorf("options should be echoed with empty lists as arrays: %+v", decoded.Options)
+	}
+	if decoded.Summary.Modules != 2 || decoded.Summary.Entities != 1 || decoded.Summary.Findings != 1 || decoded.Summary.Skipped != 1 {
+		t.Errorf("unexpected summary: %+v", decoded.Summary)
+	}
+	if decoded.Modules[0].Name != "root" || decoded.Modules[1].Status != ModuleStatusError || decoded.Modules[1].Error != "init failed" {
+		t.Errorf("unexpected modules: %+v", decoded.Modules)
+	}
+	finding := decoded.Findings[0]
+	if finding.Address != "azurerm_resource_group.rg" || !finding.Required || finding.Message == "" {
+		t.Errorf("unexpected finding: %+v", finding)
+	}
+	if finding.Description != "The Azure region the resource group lives in." || finding.Sensitive {
+		t.Errorf("unexpected finding description: %+v", finding)
+	}
+	if finding.Location.File != "main.tf" || finding.Location.StartLine != 3 {
+		t.Errorf("unexpected finding location: %+v", finding.Location)
+	}
+	if decoded.Skipped[0].Reason != SkipReasonExcluded {
+		t.Errorf("unexpected skipped entries: %+v", decoded.Skipped)
+	}
+}
+
+func TestValidateSchemaWritesReportFile(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.tf"), "# test")
+	output := filepath.Join(t.TempDir(), "report.json")
+
+	parser := &validateStubParser{
+		providerSource: "registry.terraform.io/hashicorp/azurerm",
+		resources: []ParsedResource{
+			{Type: "azurerm_resource_group", Name: "rg", Data: NewBlockData()},
+			{Type: "azurerm_role_assignment", Name: "ra", Data: NewBlockData()},
+			{Type: "azurerm_unknown_thing", Name: "x", Data: NewBlockData()},
+		},
+	}
+	runner := &validateStubRunner{
+		schema: &TerraformSchema{
+			ProviderSchemas: map[string]*ProviderSchema{
+				parser.providerSource: {
+					ResourceSchemas: map[string]*ResourceSchema{
+						"azurerm_resource_group": {Block: &SchemaBlock{
+							Attributes: map[string]*SchemaAttribute{"location": {Required: true}},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	var stdout bytes.Buffer
+	findings, err := ValidateSchema(
+		WithTerraformRoot(root),
+		WithParser(parser),
+		WithTerraformRunner(runner),
+		WithExcludedResources("azurerm_role_assignment"),
+		WithOutputFormat(FormatJSON),
+		WithOutputFile(output),
+		WithOutput(&stdout),
+		func(opts *SchemaValidatorOptions) {
+			opts.Logger = &MockLogger{}
+		},
+	)
+	if err != nil {
+		t.Fatalf("ValidateSchema returned error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("report should only be written to the output file, stdout got %q", stdout.String())
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report file: %v", err)
+	}
+
+	var decoded struct {
+		Skipped []struct {
+			Address string `json:"address"`
+			Reason  string `json:"reason"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("report file is not valid JSON: %v", err)
+	}
+
+	reasons := map[string]string{}
+	for _, skipped := range decoded.Skipped {
+		reasons[skipped.Address] = skipped.Reason
+	}
+	if reasons["azurerm_role_assignment.ra"] != SkipReasonExcluded || reasons["azurerm_unknown_thing.x"] != SkipReasonNoSchema {
+		t.Fatalf("unexpected skipped entries: %v", reasons)
+	}
+}
@@ -0,0 +1,158 @@
+package diffy
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type JUnitFormatter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+func (f *JUnitFormatter) Format(w io.Writer, report *Report) error {
+	suites := junitTestSuites{
+		Name: "diffy",
+		Time: junitSeconds(report.Duration),
+	}
+
+	for _, module := range report.Modules {
+		suite := newJUnitTestSuite(module, report)
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newJUnitTestSuite(module ModuleReport, report *Report) junitTestSuite {
+	suite := junitTestSuite{
+		Name: module.DisplayName(),
+		Time: junitSeconds(module.Duration),
+	}
+	if !report.StartedAt.IsZero() {
+		suite.Timestamp = report.StartedAt.UTC().Format(time.RFC3339)
+	}
+
+	if module.Status == ModuleStatusError {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      module.DisplayName(),
+			ClassName: module.DisplayName(),
+			Time:      junitSeconds(0),
+			Error:     &junitMessage{Message: "module validation failed", Type: "error", Body: module.Error},
+		})
+		suite.Tests, suite.Errors = 1, 1
+		return suite
+	}
+
+	findingsByAddress := make(map[string][]ValidationFinding)
+	for _, finding := range report.Findings {
+		if finding.SubmoduleName == module.Name {
+			findingsByAddress[finding.Address] = append(findingsByAddress[finding.Address], finding)
+		}
+	}
+
+	for _, entity := range module.Entities {
+		testCase := junitTestCase{
+			Name:      entity.Address,
+			ClassName: module.DisplayName(),
+			Time:      junitSeconds(0),
+		}
+		if entity.Range.Filename != "" {
+			testCase.File = displayPath(entity.Range.Filename)
+			testCase.Line = entity.Range.Start.Line
+		}
+
+		var failures, notes []string
+		for _, finding := range findingsByAddress[entity.Address] {
+			if finding.fails() {
+				failures = append(failures, FormatFinding(finding))
+			} else {
+				notes = append(notes, FormatFinding(finding))
+			}
+		}
+		if len(failures) > 0 {
+			testCase.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d findings", len(failures)),
+				Type:    "diffy",
+				Body:    strings.Join(failures, "\n"),
+			}
+			suite.Failures++
+		}
+		// Notes never fail a module, so they are kept as output of an otherwise passing test case.
+		testCase.SystemOut = strings.Join(notes, "\n")
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	for _, skipped := range module.Skipped {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      skipped.Address,
+			ClassName: module.DisplayName(),
+			Time:      junitSeconds(0),
+			Skipped:   &junitMessage{Message: skipped.Reason},
+		})
+		suite.Skipped++
+	}
+
+	suite.Tests = len(suite.Cases)
+	return suite
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
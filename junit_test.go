@@ -0,0 +1,123 @@
+package diffy
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestJUnitFormatter(t *testing.T) {
+	findings := []ValidationFinding{
+		{ResourceType: "azurerm_resource_group", Address: "azurerm_resource_group.rg", Path: "root", Name: "location", Required: true},
+		{ResourceType: "azurerm_resource_group", Address: "azurerm_resource_group.rg", Path: "root", Name: "tags"},
+	}
+
+	report := &Report{
+		StartedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration:  2 * time.Second,
+		Modules: []ModuleReport{
+			{
+				Status: ModuleStatusFailed,
+				Entities: []EntityReport{
+					{Address: "azurerm_resource_group.rg", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 7}}},
+					{Address: "data.azurerm_client_config.current"},
+				},
+				Skipped: []SkippedEntity{{Address: "azurerm_role_assignment.ra", Reason: SkipReasonExcluded}},
+			},
+			{Name: "network", Status: ModuleStatusError, Error: "terraform init failed"},
+		},
+		Findings: findings,
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatJUnit, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(out.String(), "<?xml") {
+		t.Fatalf("output should start with an XML header, got %q", out.String())
+	}
+
+	var decoded junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out.String())
+	}
+
+	if decoded.Tests != 4 || decoded.Failures != 1 || decoded.Errors != 1 || decoded.Skipped != 1 {
+		t.Fatalf("unexpected totals: tests=%d failures=%d errors=%d skipped=%d",
+			decoded.Tests, decoded.Failures, decoded.Errors, decoded.Skipped)
+	}
+	if len(decoded.Suites) != 2 || decoded.Suites[0].Name != "root" || decoded.Suites[1].Name != "network" {
+		t.Fatalf("expected one suite per module, got %+v", decoded.Suites)
+	}
+
+	root := decoded.Suites[0]
+	failing := root.Cases[0]
+	if failing.Name != "azurerm_resource_group.rg" || failing.File != "main.tf" || failing.Line != 7 {
+		t.Errorf("unexpected failing testcase: %+v", failing)
+	}
+	if failing.Failure == nil || !strings.Contains(failing.Failure.Body, "location") || !strings.Contains(failing.Failure.Body, "tags") {
+		t.Errorf("failure should list the missing attributes: %+v", failing.Failure)
+	}
+	if failing.Failure != nil && failing.Failure.Message != "2 findings" {
+		t.Errorf("failure message = %q, want %q", failing.Failure.Message, "2 findings")
+	}
+	if root.Cases[1].Failure != nil {
+		t.Errorf("resource without findings should pass: %+v", root.Cases[1])
+	}
+	if root.Cases[2].Skipped == nil || root.Cases[2].Skipped.Message != SkipReasonExcluded {
+		t.Errorf("excluded resource should be skipped: %+v", root.Cases[2])
+	}
+
+	errored := decoded.Suites[1].Cases[0]
+	if errored.Error == nil || errored.Error.Body != "terraform init failed" {
+		t.Errorf("module error should be reported as a testcase error: %+v", errored)
+	}
+}
+
+func TestJUnitFormatterKeepsNotesOutOfFailures(t *testing.T) {
+	report := &Report{
+		Modules: []ModuleReport{
+			{
+				Status: ModuleStatusPassed,
+				Entities: []EntityReport{
+					{Address: "azurerm_storage_account.sa"},
+					{Address: "azurerm_key_vault.kv"},
+				},
+			},
+		},
+		Findings: []ValidationFinding{
+			{Kind: FindingKindUnknownCardinality, ResourceType: "azurerm_storage_account", Address: "azurerm_storage_account.sa", Path: "root", Name: "identity", IsBlock: true, Detail: "dynamic block count cannot be checked against max_items 1"},
+			{ResourceType: "azurerm_key_vault", Address: "azurerm_key_vault.kv", Path: "root", Name: "sku_name", Required: true},
+			{ResourceType: "azurerm_key_vault", Address: "azurerm_key_vault.kv", Path: "root", Name: "tags", Severity: SeverityNote},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatJUnit, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	var decoded junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out.String())
+	}
+	if decoded.Failures != 1 {
+		t.Fatalf("only the entity with a failing finding should fail, got %d failures", decoded.Failures)
+	}
+
+	noted, failing := decoded.Suites[0].Cases[0], decoded.Suites[0].Cases[1]
+	if noted.Failure != nil || !strings.Contains(noted.SystemOut, "identity") {
+		t.Errorf("a note-only entity should pass and keep its note as output: %+v", noted)
+	}
+	if failing.Failure == nil || failing.Failure.Message != "1 findings" || strings.Contains(failing.Failure.Body, "tags") {
+		t.Errorf("the failure should only list failing findings: %+v", failing.Failure)
+	}
+	if !strings.Contains(failing.SystemOut, "tags") {
+		t.Errorf("notes of a failing entity should be kept as output: %q", failing.SystemOut)
+	}
+}
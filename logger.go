@@ -3,10 +3,18 @@ package diffy
 
 import (
 	"fmt"
+	"io"
+	"os"
 )
 
-type SimpleLogger struct{}
+type SimpleLogger struct {
+	Writer io.Writer
+}
 
 func (l *SimpleLogger) Logf(format string, args ...any) {
-	fmt.Printf(format+"\n", args...)
+	w := l.Writer
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format+"\n", args...)
 }
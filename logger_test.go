@@ -1,6 +1,8 @@
 package diffy
 
 import (
+	"io"
+	"os"
 	"strings"
 	"testing"
 )
@@ -45,6 +47,35 @@ func TestSimpleLogger_Logf(t *testing.T) {
 	}
 }
 
+func TestSimpleLoggerDefaultsToStderr(t *testing.T) {
+	capture := func(target **os.File) func() string {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		original := *target
+		*target = w
+		return func() string {
+			*target = original
+			w.Close()
+			out, _ := io.ReadAll(r)
+			return string(out)
+		}
+	}
+
+	stdout := capture(&os.Stdout)
+	stderr := capture(&os.Stderr)
+	(&SimpleLogger{}).Logf("No provider config for %s", "azurerm_key_vault")
+
+	if got := stdout(); got != "" {
+		t.Errorf("stdout = %q, should stay free for reports", got)
+	}
+	if got := stderr(); got != "No provider config for azurerm_key_vault\n" {
+		t.Errorf("stderr = %q, want the log line", got)
+	}
+}
+
 func TestSimpleLogger_Interface(t *testing.T) {
 	var _ Logger = (*SimpleLogger)(nil)
 
@@ -0,0 +1,116 @@
+package diffy
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// nestedObject is an object literal assigned to a nested attribute, with the validation path it is reported under.
+type nestedObject struct {
+	path   string
+	object *hclsyntax.ObjectConsExpr
+}
+
+// validateNestedAttribute checks the object literals assigned to a nested attribute against its nested type.
+// Values that are only known at plan time, such as variables or function calls, are skipped. Findings point at
+// the enclosing block, like findings for attributes of that block, so suppressions written above it apply.
+func validateNestedAttribute(
+	resourceType, path string,
+	nested *SchemaNestedType,
+	expr hclsyntax.Expression,
+	owner hcl.Range,
+	ignore []string,
+	exclusions AttributeExclusions,
+	findings *[]ValidationFinding,
+) {
+	schema := &SchemaBlock{Attributes: nested.Attributes}
+
+	for _, object := range nestedObjects(path, nested, expr) {
+		data := objectBlockData(object.object)
+		data.Range = owner
+		data.ValidateWithExclusions(resourceType, object.path, schema, ignore, exclusions, findings)
+	}
+}
+
+// nestedObjects finds the object literals in a nested attribute value according to its nesting mode,
+// looking through tuple and object constructors as well as the result expression of for expressions.
+func nestedObjects(path string, nested *SchemaNestedType, expr hclsyntax.Expression) []nestedObject {
+	var objects []nestedObject
+
+	add := func(path string, expr hclsyntax.Expression) {
+		if object, ok := expr.(*hclsyntax.ObjectConsExpr); ok {
+			objects = append(objects, nestedObject{path: path, object: object})
+		}
+	}
+
+	switch nested.NestingMode {
+	case "list", "set":
+		switch e := expr.(type) {
+		case *hclsyntax.TupleConsExpr:
+			for i, element := range e.Exprs {
+				elementPath := path
+				if len(e.Exprs) > 1 {
+					elementPath = fmt.Sprintf("%s[%d]", path, i)
+				}
+				add(elementPath, element)
+			}
+		case *hclsyntax.ForExpr:
+			if e.KeyExpr == nil {
+				add(path, e.ValExpr)
+			}
+		}
+	case "map":
+		switch e := expr.(type) {
+		case *hclsyntax.ObjectConsExpr:
+			for _, item := range e.Items {
+				add(path, item.ValueExpr)
+			}
+		case *hclsyntax.ForExpr:
+			if e.KeyExpr != nil {
+				add(path, e.ValExpr)
+			}
+		}
+	default:
+		add(path, expr)
+	}
+
+	return objects
+}
+
+// objectBlockData exposes the keys of an object literal the same way block bodies are parsed,
+// so nested attributes are validated by the same rules as nested blocks.
+func objectBlockData(object *hclsyntax.ObjectConsExpr) BlockData {
+	data := NewBlockData()
+
+	for _, item := range object.Items {
+		name, ok := objectKey(item.KeyExpr)
+		if !ok {
+			continue
+		}
+		data.Properties[name] = true
+		data.Attributes[name] = &hclsyntax.Attribute{
+			Name:      name,
+			Expr:      item.ValueExpr,
+			SrcRange:  hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()),
+			NameRange: item.KeyExpr.Range(),
+		}
+	}
+
+	return data
+}
+
+// objectKey resolves a literal object key, whether written as a bare identifier or a quoted string.
+func objectKey(expr hclsyntax.Expression) (string, bool) {
+	if keyword := hcl.ExprAsKeyword(expr); keyword != "" {
+		return keyword, true
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return "", false
+	}
+	return value.AsString(), true
+}
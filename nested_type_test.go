@@ -0,0 +1,164 @@
+package diffy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestSchemaAttributeDecodesNestedType(t *testing.T) {
+	var attribute SchemaAttribute
+	err := json.Unmarshal([]byte(`{
+  "optional": true,
+  "nested_type": {
+    "nesting_mode": "list",
+    "min_items": 1,
+    "attributes": {
+      "name": {"required": true},
+      "port": {"optional": true}
+    }
+  }
+}`), &attribute)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := &SchemaNestedType{
+		NestingMode: "list",
+		MinItems:    1,
+		Attributes: map[string]*SchemaAttribute{
+			"name": {Required: true},
+			"port": {Optional: true},
+		},
+	}
+	if diff := cmp.Diff(want, attribute.NestedType); diff != "" {
+		t.Fatalf("NestedType mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBlockDataValidateNestedAttributes(t *testing.T) {
+	body := parseHCLBody(t, `
+identity = {
+  type = "UserAssigned"
+}
+
+rules = [
+  {
+    name = "allow-http"
+    prot = 80
+  },
+  {
+    "name"      = "allow-https"
+    port        = 443
+    rule_id     = "computed"
+  },
+]
+
+routes = [for route in var.routes : {
+  next_hop = route.next_hop
+}]
+
+endpoints = {
+  primary = {
+    address = "10.0.0.1"
+  }
+}
+
+dynamic_rules = var.rules
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	rule := &SchemaNestedType{
+		NestingMode: "list",
+		Attributes: map[string]*SchemaAttribute{
+			"name":    {Required: true},
+			"port":    {Optional: true},
+			"rule_id": {Computed: true},
+		},
+	}
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"identity": {Optional: true, NestedType: &SchemaNestedType{
+				NestingMode: "single",
+				Attributes: map[string]*SchemaAttribute{
+					"type":         {Required: true},
+					"identity_ids": {Optional: true},
+				},
+			}},
+			"rules":         {Optional: true, NestedType: rule},
+			"dynamic_rules": {Optional: true, NestedType: rule},
+			"routes": {Optional: true, NestedType: &SchemaNestedType{
+				NestingMode: "set",
+				Attributes: map[string]*SchemaAttribute{
+					"name":     {Required: true},
+					"next_hop": {Optional: true},
+				},
+			}},
+			"endpoints": {Optional: true, NestedType: &SchemaNestedType{
+				NestingMode: "map",
+				Attributes: map[string]*SchemaAttribute{
+					"address": {Required: true},
+					"weight":  {Optional: true},
+				},
+			}},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azapi_resource", "root", schema, nil, &findings)
+
+	for i := range findings {
+		findings[i].Range = hcl.Range{}
+	}
+
+	want := []ValidationFinding{
+		{ResourceType: "azapi_resource", Path: "root.identity", Name: "identity_ids"},
+		{ResourceType: "azapi_resource", Path: "root.rules[0]", Name: "port"},
+		{Kind: FindingKindUnknown, ResourceType: "azapi_resource", Path: "root.rules[0]", Name: "prot", Detail: `did you mean "port"?`},
+		{Kind: FindingKindComputed, ResourceType: "azapi_resource", Path: "root.rules[1]", Name: "rule_id"},
+		{ResourceType: "azapi_resource", Path: "root.routes", Name: "name", Required: true},
+		{ResourceType: "azapi_resource", Path: "root.endpoints", Name: "weight"},
+	}
+
+	sortFindings := cmpopts.SortSlices(func(a, b ValidationFinding) bool {
+		return a.Path+"."+a.Name < b.Path+"."+b.Name
+	})
+	if diff := cmp.Diff(want, findings, sortFindings); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateNestedAttributeUsesOwningRange(t *testing.T) {
+	body := parseHCLBody(t, `
+identity = {
+  type = "UserAssigned"
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+	nested := &SchemaNestedType{
+		NestingMode: "single",
+		Attributes: map[string]*SchemaAttribute{
+			"identity_ids": {Required: true},
+			"type":         {Required: true},
+		},
+	}
+
+	var findings []ValidationFinding
+	validateNestedAttribute("azapi_resource", "root.identity", nested, parsed.Data.Attributes["identity"].Expr, parsed.Data.Range, nil, AttributeExclusions{}, &findings)
+
+	want := []ValidationFinding{{
+		ResourceType: "azapi_resource",
+		Path:         "root.identity",
+		Name:         "identity_ids",
+		Required:     true,
+		Range:        parsed.Data.Range,
+	}}
+	if diff := cmp.Diff(want, findings); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -19,6 +19,23 @@ type HCLParser interface {
 	ParseTerraformFiles(ctx context.Context, filenames []string) ([]ParsedResource, []ParsedDataSource, error)
 }
 
+// EphemeralResourceParser is implemented by parsers that also read ephemeral blocks (Terraform 1.10+).
+// It is kept apart from HCLParser so existing parser implementations keep working.
+type EphemeralResourceParser interface {
+	ParseEphemeralResources(ctx context.Context, filenames []string) ([]ParsedEphemeralResource, error)
+}
+
+// ProviderBlockParser is implemented by parsers that also read provider configuration blocks.
+type ProviderBlockParser interface {
+	ParseProviderBlocks(ctx context.Context, filenames []string) ([]ParsedProvider, error)
+}
+
+// ModuleFileParser is implemented by parsers that read resources, data sources, ephemeral resources and
+// provider blocks in one pass over a module's files, instead of once per block kind.
+type ModuleFileParser interface {
+	ParseModuleFiles(ctx context.Context, filenames []string) (*ParsedModule, error)
+}
+
 type TerraformRunner interface {
 	Init(ctx context.Context, dir string) error
 	GetSchema(ctx context.Context, dir string) (*TerraformSchema, error)
@@ -51,34 +68,112 @@ func (parser *DefaultHCLParser) ParseMainFile(ctx context.Context, filename stri
 	return parser.ParseTerraformFiles(ctx, []string{filename})
 }
 
-func (parser *DefaultHCLParser) ParseTerraformFiles(_ context.Context, files []string) ([]ParsedResource, []ParsedDataSource, error) {
-	var allResources []ParsedResource
-	var allDataSources []ParsedDataSource
+func (parser *DefaultHCLParser) ParseTerraformFiles(ctx context.Context, files []string) ([]ParsedResource, []ParsedDataSource, error) {
+	module, err := parser.ParseModuleFiles(ctx, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	return module.Resources, module.DataSources, nil
+}
+
+func (parser *DefaultHCLParser) ParseEphemeralResources(ctx context.Context, files []string) ([]ParsedEphemeralResource, error) {
+	module, err := parser.ParseModuleFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	return module.EphemeralResources, nil
+}
+
+func (parser *DefaultHCLParser) ParseProviderBlocks(ctx context.Context, files []string) ([]ParsedProvider, error) {
+	module, err := parser.ParseModuleFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	return module.Providers, nil
+}
 
+func (parser *DefaultHCLParser) ParseModuleFiles(_ context.Context, files []string) (*ParsedModule, error) {
+	module := &ParsedModule{}
 	for _, filename := range files {
-		f, err := parser.parseHCLFile(filename)
+		parsed, err := parser.parseTerraformFile(filename)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
+		module.Resources = append(module.Resources, parsed.Resources...)
+		module.DataSources = append(module.DataSources, parsed.DataSources...)
+		module.EphemeralResources = append(module.EphemeralResources, parsed.EphemeralResources...)
+		module.Providers = append(module.Providers, parsed.Providers...)
+	}
+	return module, nil
+}
 
-		body, ok := f.Body.(*hclsyntax.Body)
-		if !ok {
-			return nil, nil, &ParseError{
-				File:    filename,
-				Message: "invalid HCL body type",
-			}
+// parseTerraformFile parses a file and lexes its diffy:ignore comments once, attaching them to every block read from it.
+func (parser *DefaultHCLParser) parseTerraformFile(filename string) (*ParsedModule, error) {
+	f, err := parser.parseHCLFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, &ParseError{
+			File:    filename,
+			Message: "invalid HCL body type",
 		}
+	}
 
-		resources, dataSources, err := parser.parseMainFileFromBody(body)
-		if err != nil {
-			return nil, nil, err
+	resources, dataSources, err := parser.parseMainFileFromBody(body)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &ParsedModule{
+		Resources:          resources,
+		DataSources:        dataSources,
+		EphemeralResources: parser.parseEphemeralResourcesFromBody(body),
+		Providers:          parser.parseProviderBlocksFromBody(body),
+	}
+
+	if suppressions := ParseSuppressions(f.Bytes, filename); len(suppressions) > 0 {
+		for i := range parsed.Resources {
+			parsed.Resources[i].Data.AttachSuppressions(suppressions)
+		}
+		for i := range parsed.DataSources {
+			parsed.DataSources[i].Data.AttachSuppressions(suppressions)
+		}
+		for i := range parsed.EphemeralResources {
+			parsed.EphemeralResources[i].Data.AttachSuppressions(suppressions)
 		}
+		for i := range parsed.Providers {
+			parsed.Providers[i].Data.AttachSuppressions(suppressions)
+		}
+	}
+	return parsed, nil
+}
 
-		allResources = append(allResources, resources...)
-		allDataSources = append(allDataSources, dataSources...)
+// parseModuleFiles reads every block kind diffy validates from a module's files, in a single pass when the
+// parser implements ModuleFileParser and through the narrower parser interfaces otherwise.
+func parseModuleFiles(ctx context.Context, parser HCLParser, files []string) (*ParsedModule, error) {
+	if moduleParser, ok := parser.(ModuleFileParser); ok {
+		return moduleParser.ParseModuleFiles(ctx, files)
 	}
 
-	return allResources, allDataSources, nil
+	module := &ParsedModule{}
+	var err error
+	module.Resources, module.DataSources, err = parser.ParseTerraformFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	if ephemeralParser, ok := parser.(EphemeralResourceParser); ok {
+		if module.EphemeralResources, err = ephemeralParser.ParseEphemeralResources(ctx, files); err != nil {
+			return nil, err
+		}
+	}
+	if providerParser, ok := parser.(ProviderBlockParser); ok {
+		if module.Providers, err = providerParser.ParseProviderBlocks(ctx, files); err != nil {
+			return nil, err
+		}
+	}
+	return module, nil
 }
 
 func (parser *DefaultHCLParser) parseHCLFile(filename string) (*hcl.File, error) {
@@ -118,6 +213,7 @@ func (parser *DefaultHCLParser) parseProviderRequirementsFromBody(body *hclsynta
 							if pc.Source == "" {
 								pc.Source = NormalizeSource("hashicorp/" + name)
 							}
+							pc.ConfigurationAliases = configurationAliases(attr.Expr)
 							providers[name] = pc
 						}
 					}
@@ -128,6 +224,36 @@ func (parser *DefaultHCLParser) parseProviderRequirementsFromBody(body *hclsynta
 	return providers, nil
 }
 
+// configurationAliases reads configuration_aliases from a required_providers entry. The aliases are
+// references such as azurerm.secondary, which do not evaluate without a context, so they are read from syntax.
+func configurationAliases(expr hcl.Expression) []string {
+	object, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return nil
+	}
+
+	var aliases []string
+	for _, item := range object.Items {
+		if hcl.ExprAsKeyword(item.KeyExpr) != "configuration_aliases" {
+			continue
+		}
+		elements, diags := hcl.ExprList(item.ValueExpr)
+		if diags.HasErrors() {
+			return nil
+		}
+		for _, element := range elements {
+			traversal, diags := hcl.AbsTraversalForExpr(element)
+			if diags.HasErrors() || len(traversal) != 2 {
+				continue
+			}
+			if alias, ok := traversal[1].(hcl.TraverseAttr); ok {
+				aliases = append(aliases, alias.Name)
+			}
+		}
+	}
+	return aliases
+}
+
 func (parser *DefaultHCLParser) parseMainFileFromBody(body *hclsyntax.Body) ([]ParsedResource, []ParsedDataSource, error) {
 	var resources []ParsedResource
 	var dataSources []ParsedDataSource
@@ -135,6 +261,7 @@ func (parser *DefaultHCLParser) parseMainFileFromBody(body *hclsyntax.Body) ([]P
 	for _, blk := range body.Blocks {
 		if blk.Type == "resource" && len(blk.Labels) >= 2 {
 			parsed := ParseSyntaxBody(blk.Body)
+			parsed.Data.Range = blk.Range()
 
 			res := ParsedResource{
 				Type: blk.Labels[0],
@@ -146,6 +273,7 @@ func (parser *DefaultHCLParser) parseMainFileFromBody(body *hclsyntax.Body) ([]P
 
 		if blk.Type == "data" && len(blk.Labels) >= 2 {
 			parsed := ParseSyntaxBody(blk.Body)
+			parsed.Data.Range = blk.Range()
 
 			ds := ParsedDataSource{
 				Type: blk.Labels[0],
@@ -158,8 +286,58 @@ func (parser *DefaultHCLParser) parseMainFileFromBody(body *hclsyntax.Body) ([]P
 	return resources, dataSources, nil
 }
 
+func (parser *DefaultHCLParser) parseEphemeralResourcesFromBody(body *hclsyntax.Body) []ParsedEphemeralResource {
+	var ephemeralResources []ParsedEphemeralResource
+
+	for _, blk := range body.Blocks {
+		if blk.Type == "ephemeral" && len(blk.Labels) >= 2 {
+			parsed := ParseSyntaxBody(blk.Body)
+			parsed.Data.Range = blk.Range()
+
+			ephemeralResources = append(ephemeralResources, ParsedEphemeralResource{
+				Type: blk.Labels[0],
+				Name: blk.Labels[1],
+				Data: parsed.Data,
+			})
+		}
+	}
+	return ephemeralResources
+}
+
+// providerMetaArguments are handled by Terraform itself and never appear in a provider's schema.
+var providerMetaArguments = []string{"alias", "version"}
+
+func (parser *DefaultHCLParser) parseProviderBlocksFromBody(body *hclsyntax.Body) []ParsedProvider {
+	var providerBlocks []ParsedProvider
+
+	for _, blk := range body.Blocks {
+		if blk.Type != "provider" || len(blk.Labels) < 1 {
+			continue
+		}
+
+		parsed := ParseSyntaxBody(blk.Body)
+		parsed.Data.Range = blk.Range()
+
+		provider := ParsedProvider{Name: blk.Labels[0]}
+		if attr, ok := blk.Body.Attributes["alias"]; ok {
+			if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String && val.IsKnown() && !val.IsNull() {
+				provider.Alias = val.AsString()
+			}
+		}
+		for _, name := range providerMetaArguments {
+			delete(parsed.Data.Properties, name)
+			delete(parsed.Data.Attributes, name)
+		}
+		provider.Data = parsed.Data
+
+		providerBlocks = append(providerBlocks, provider)
+	}
+	return providerBlocks
+}
+
 func ParseSyntaxBody(body *hclsyntax.Body) *ParsedBlock {
 	bd := NewBlockData()
+	bd.Range = body.SrcRange
 	bd.ParseAttributes(body)
 	bd.ParseBlocks(body)
 	return &ParsedBlock{Data: bd}
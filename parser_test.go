@@ -190,6 +190,30 @@ terraform {
 				}
 			},
 		},
+		{
+			name: "configuration aliases",
+			tfContent: `
+terraform {
+  required_providers {
+    azurerm = {
+      source                = "hashicorp/azurerm"
+      configuration_aliases = [azurerm.hub, azurerm.spoke]
+    }
+  }
+}
+`,
+			wantErr:   false,
+			wantCount: 1,
+			checkResult: func(t *testing.T, providers map[string]ProviderConfig) {
+				provider := providers["azurerm"]
+				if provider.Source != "registry.terraform.io/hashicorp/azurerm" {
+					t.Errorf("Source = %s, want registry.terraform.io/hashicorp/azurerm", provider.Source)
+				}
+				if len(provider.ConfigurationAliases) != 2 || provider.ConfigurationAliases[0] != "hub" || provider.ConfigurationAliases[1] != "spoke" {
+					t.Errorf("ConfigurationAliases = %v, want [hub spoke]", provider.ConfigurationAliases)
+				}
+			},
+		},
 		{
 			name: "no terraform block",
 			tfContent: `
@@ -388,3 +412,200 @@ func TestParseHCLFile_InvalidSyntax(t *testing.T) {
 		t.Error("ParseMainFile() should return error for invalid HCL syntax")
 	}
 }
+
+func TestParseMainFileRecordsSourceRanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	tfFile := filepath.Join(tmpDir, "main.tf")
+
+	content := `resource "azurerm_virtual_network" "test" {
+  name = "test"
+
+  subnet {
+    name = "a"
+  }
+
+  dynamic "ddos_protection_plan" {
+    for_each = var.plans
+    content {
+      id = ddos_protection_plan.value
+    }
+  }
+}
+
+data "azurerm_resource_group" "existing" {
+  name = "rg"
+}
+`
+	if err := os.WriteFile(tfFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resources, dataSources, err := NewHCLParser().ParseMainFile(context.Background(), tfFile)
+	if err != nil {
+		t.Fatalf("ParseMainFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		got       BlockData
+		wantStart int
+		wantEnd   int
+	}{
+		{name: "resource", got: resources[0].Data, wantStart: 1, wantEnd: 14},
+		{name: "static block", got: resources[0].Data.StaticBlocks["subnet"][0].Data, wantStart: 4, wantEnd: 6},
+		{name: "dynamic block", got: resources[0].Data.DynamicBlocks["ddos_protection_plan"].Data, wantStart: 8, wantEnd: 13},
+		{name: "data source", got: dataSources[0].Data, wantStart: 16, wantEnd: 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Range.Filename != tfFile {
+				t.Errorf("Range.Filename = %q, want %q", tt.got.Range.Filename, tfFile)
+			}
+			if tt.got.Range.Start.Line != tt.wantStart || tt.got.Range.End.Line != tt.wantEnd {
+				t.Errorf("Range lines = %d-%d, want %d-%d",
+					tt.got.Range.Start.Line, tt.got.Range.End.Line, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseEphemeralResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	tfFile := filepath.Join(tmpDir, "main.tf")
+
+	content := `resource "azurerm_key_vault" "kv" {
+  name = "kv"
+}
+
+# diffy:ignore attribute=version
+ephemeral "azurerm_key_vault_secret" "password" {
+  name         = "admin-password"
+  key_vault_id = azurerm_key_vault.kv.id
+}
+`
+	if err := os.WriteFile(tfFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	ephemeralResources, err := NewHCLParser().ParseEphemeralResources(context.Background(), []string{tfFile})
+	if err != nil {
+		t.Fatalf("ParseEphemeralResources() error = %v", err)
+	}
+	if len(ephemeralResources) != 1 {
+		t.Fatalf("Got %d ephemeral resources, want 1", len(ephemeralResources))
+	}
+
+	got := ephemeralResources[0]
+	if got.Type != "azurerm_key_vault_secret" || got.Name != "password" {
+		t.Errorf("Got %s.%s, want azurerm_key_vault_secret.password", got.Type, got.Name)
+	}
+	if !got.Data.Properties["name"] || !got.Data.Properties["key_vault_id"] {
+		t.Errorf("Properties = %v, want name and key_vault_id", got.Data.Properties)
+	}
+	if got.Data.Range.Start.Line != 6 || got.Data.Range.End.Line != 9 {
+		t.Errorf("Range lines = %d-%d, want 6-9", got.Data.Range.Start.Line, got.Data.Range.End.Line)
+	}
+	if len(got.Data.Suppressions) != 1 || got.Data.Suppressions[0].Name != "version" {
+		t.Errorf("Suppressions = %+v, want one suppression for version", got.Data.Suppressions)
+	}
+}
+
+func TestParseProviderBlocks(t *testing.T) {
+	tmpDir := t.TempDir()
+	tfFile := filepath.Join(tmpDir, "providers.tf")
+
+	content := `provider "azurerm" {
+  features {}
+}
+
+# diffy:ignore attribute=subscription_id
+provider "azurerm" {
+  alias           = "secondary"
+  version         = "~> 4.0"
+  subscription_id = var.secondary_subscription_id
+  features {}
+}
+`
+	if err := os.WriteFile(tfFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	providerBlocks, err := NewHCLParser().ParseProviderBlocks(context.Background(), []string{tfFile})
+	if err != nil {
+		t.Fatalf("ParseProviderBlocks() error = %v", err)
+	}
+	if len(providerBlocks) != 2 {
+		t.Fatalf("Got %d provider blocks, want 2", len(providerBlocks))
+	}
+
+	if got := providerBlocks[0]; got.Name != "azurerm" || got.Alias != "" || len(got.Data.StaticBlocks["features"]) != 1 {
+		t.Errorf("default provider = %+v, want azurerm without alias and with features", got)
+	}
+
+	aliased := providerBlocks[1]
+	if aliased.Name != "azurerm" || aliased.Alias != "secondary" {
+		t.Errorf("Got provider %q alias %q, want azurerm alias secondary", aliased.Name, aliased.Alias)
+	}
+	if aliased.Data.Properties["alias"] || aliased.Data.Properties["version"] || aliased.Data.Attributes["alias"] != nil {
+		t.Errorf("meta-arguments should not be validated as provider arguments, got %v", aliased.Data.Properties)
+	}
+	if !aliased.Data.Properties["subscription_id"] {
+		t.Errorf("Properties = %v, want subscription_id", aliased.Data.Properties)
+	}
+	if len(aliased.Data.Suppressions) != 1 || aliased.Data.Suppressions[0].Name != "subscription_id" {
+		t.Errorf("Suppressions = %+v, want one suppression for subscription_id", aliased.Data.Suppressions)
+	}
+}
+
+func TestParseModuleFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainFile := filepath.Join(tmpDir, "main.tf")
+	providersFile := filepath.Join(tmpDir, "providers.tf")
+
+	main := `# diffy:ignore attribute=tags
+resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}
+
+# diffy:ignore attribute=tenant_id
+data "azurerm_client_config" "current" {}
+
+# diffy:ignore attribute=length
+ephemeral "random_password" "admin" {}
+`
+	providers := `# diffy:ignore attribute=subscription_id
+provider "azurerm" {
+  features {}
+}
+`
+	for file, content := range map[string]string{mainFile: main, providersFile: providers} {
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	module, err := NewHCLParser().ParseModuleFiles(context.Background(), []string{mainFile, providersFile})
+	if err != nil {
+		t.Fatalf("ParseModuleFiles() error = %v", err)
+	}
+	if len(module.Resources) != 1 || len(module.DataSources) != 1 || len(module.EphemeralResources) != 1 || len(module.Providers) != 1 {
+		t.Fatalf("ParseModuleFiles() = %+v, want one block of every kind", module)
+	}
+
+	suppressed := map[string][]Suppression{
+		"resource":  module.Resources[0].Data.Suppressions,
+		"data":      module.DataSources[0].Data.Suppressions,
+		"ephemeral": module.EphemeralResources[0].Data.Suppressions,
+		"provider":  module.Providers[0].Data.Suppressions,
+	}
+	for kind, suppressions := range suppressed {
+		if len(suppressions) != 1 {
+			t.Errorf("%s block should carry the suppression above it, got %+v", kind, suppressions)
+		}
+	}
+
+	if _, err := NewHCLParser().ParseModuleFiles(context.Background(), []string{filepath.Join(tmpDir, "missing.tf")}); err == nil {
+		t.Error("ParseModuleFiles() should fail for a file that cannot be parsed")
+	}
+}
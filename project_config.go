@@ -0,0 +1,228 @@
+package diffy
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+const ProjectConfigFile = ".diffy.hcl"
+
+// ProjectConfig is the content of a .diffy.hcl file at the Terraform root or in a submodule.
+type ProjectConfig struct {
+	Exclude     *ProjectExclusions   `hcl:"exclude,block"`
+	Resources   []ProjectResource    `hcl:"resource,block"`
+	DataSources []ProjectResource    `hcl:"data,block"`
+	Ephemeral   []ProjectResource    `hcl:"ephemeral,block"`
+	Suppress    []ProjectSuppression `hcl:"suppress,block"`
+	Severity    map[string]string    `hcl:"severity,optional"`
+	Output      *ProjectOutput       `hcl:"output,block"`
+	GitHub      *ProjectGitHub       `hcl:"github,block"`
+}
+
+type ProjectExclusions struct {
+	Resources   []string `hcl:"resources,optional"`
+	DataSources []string `hcl:"data_sources,optional"`
+	Ephemeral   []string `hcl:"ephemeral_resources,optional"`
+	Attributes  []string `hcl:"attributes,optional"`
+}
+
+// ProjectResource lists attribute or block paths, relative to the resource, whose findings are ignored.
+type ProjectResource struct {
+	Type   string   `hcl:"type,label"`
+	Ignore []string `hcl:"ignore,optional"`
+}
+
+// ProjectSuppression accepts findings matching an attribute pattern until it expires.
+type ProjectSuppression struct {
+	Pattern string `hcl:"pattern,label"`
+	Owner   string `hcl:"owner,optional"`
+	Reason  string `hcl:"reason,optional"`
+	Expires string `hcl:"expires,optional"`
+}
+
+type ProjectOutput struct {
+	Format string `hcl:"format,optional"`
+	File   string `hcl:"file,optional"`
+}
+
+type ProjectGitHub struct {
+	Issue   *bool  `hcl:"issue,optional"`
+	Actions *bool  `hcl:"actions,optional"`
+	Owner   string `hcl:"owner,optional"`
+	Repo    string `hcl:"repo,optional"`
+}
+
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	f, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, &ParseError{File: path, Message: "failed to parse configuration", Err: diags}
+	}
+
+	var config ProjectConfig
+	if diags := gohcl.DecodeBody(f.Body, nil, &config); diags.HasErrors() {
+		return nil, &ParseError{File: path, Message: "invalid configuration", Err: diags}
+	}
+
+	for rule, level := range config.Severity {
+		if err := validateSeverityOverride(rule, Severity(level)); err != nil {
+			return nil, &ParseError{File: path, Message: err.Error()}
+		}
+	}
+
+	for _, suppression := range config.Suppress {
+		if NewAttributeExclusions(suppression.Pattern).IsEmpty() {
+			return nil, &ParseError{File: path, Message: fmt.Sprintf("invalid suppression pattern %q", suppression.Pattern)}
+		}
+		if err := suppression.metadata().Validate(); err != nil {
+			return nil, &ParseError{File: path, Message: fmt.Sprintf("suppression %q", suppression.Pattern), Err: err}
+		}
+	}
+
+	return &config, nil
+}
+
+func (suppression ProjectSuppression) metadata() SuppressionMetadata {
+	return SuppressionMetadata{Owner: suppression.Owner, Reason: suppression.Reason, Expires: suppression.Expires}
+}
+
+// loadProjectConfigIn returns the .diffy.hcl in dir, or nil when there is none.
+func loadProjectConfigIn(dir string) (*ProjectConfig, error) {
+	path := filepath.Join(dir, ProjectConfigFile)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return LoadProjectConfig(path)
+}
+
+// Apply merges the configuration into opts; list and map settings are added to what is already there.
+func (config *ProjectConfig) Apply(opts *SchemaValidatorOptions) {
+	config.applyModuleSettings(opts)
+
+	if output := config.Output; output != nil {
+		if output.Format != "" {
+			opts.Format = output.Format
+		}
+		if output.File != "" {
+			opts.OutputFile = output.File
+		}
+	}
+
+	if github := config.GitHub; github != nil {
+		if github.Issue != nil && *github.Issue {
+			WithGitHubIssueCreation()(opts)
+		}
+		if github.Actions != nil {
+			opts.GitHubActions = *github.Actions
+		}
+		if github.Owner != "" {
+			opts.GitHubOwner = github.Owner
+		}
+		if github.Repo != "" {
+			opts.GitHubRepo = github.Repo
+		}
+	}
+}
+
+// applyModuleSettings merges the settings that a submodule configuration may scope to itself.
+func (config *ProjectConfig) applyModuleSettings(opts *SchemaValidatorOptions) {
+	if exclude := config.Exclude; exclude != nil {
+		opts.ExcludedResources = append(opts.ExcludedResources, exclude.Resources...)
+		opts.ExcludedDataSources = append(opts.ExcludedDataSources, exclude.DataSources...)
+		opts.ExcludedEphemeral = append(opts.ExcludedEphemeral, exclude.Ephemeral...)
+		opts.ExcludedAttributes = append(opts.ExcludedAttributes, exclude.Attributes...)
+	}
+
+	for _, resource := range config.Resources {
+		WithIgnoredAttributes(resource.Type, resource.Ignore...)(opts)
+	}
+	for _, dataSource := range config.DataSources {
+		WithIgnoredAttributes("data."+dataSource.Type, dataSource.Ignore...)(opts)
+	}
+	for _, ephemeral := range config.Ephemeral {
+		WithIgnoredAttributes("ephemeral."+ephemeral.Type, ephemeral.Ignore...)(opts)
+	}
+
+	for _, suppression := range config.Suppress {
+		WithAttributeSuppression(suppression.Pattern, suppression.metadata())(opts)
+	}
+
+	for rule, level := range config.Severity {
+		WithSeverityOverride(rule, Severity(level))(opts)
+	}
+}
+
+// resolveConfigFile finds the project configuration for root, honoring an explicit ConfigFile, and returns its path.
+func resolveConfigFile(root, configFile string) (*ProjectConfig, string, error) {
+	if configFile == "" {
+		if root == "" {
+			return nil, "", nil
+		}
+		configFile = filepath.Join(root, ProjectConfigFile)
+		if _, err := os.Stat(configFile); errors.Is(err, fs.ErrNotExist) {
+			return nil, "", nil
+		}
+	}
+
+	config, err := LoadProjectConfig(configFile)
+	if err != nil {
+		return nil, "", err
+	}
+	return config, configFile, nil
+}
+
+// moduleOptions layers a submodule's own .diffy.hcl over the project options.
+func moduleOptions(opts *SchemaValidatorOptions, dir string) (*SchemaValidatorOptions, error) {
+	config, err := loadProjectConfigIn(dir)
+	if err != nil || config == nil {
+		return opts, err
+	}
+
+	scoped := *opts
+	scoped.ExcludedResources = slices.Clone(opts.ExcludedResources)
+	scoped.ExcludedDataSources = slices.Clone(opts.ExcludedDataSources)
+	scoped.ExcludedEphemeral = slices.Clone(opts.ExcludedEphemeral)
+	scoped.ExcludedAttributes = slices.Clone(opts.ExcludedAttributes)
+	scoped.Suppressions = slices.Clone(opts.Suppressions)
+	scoped.IgnoredAttributes = cloneIgnoredAttributes(opts.IgnoredAttributes)
+	scoped.SeverityOverrides = make(map[string]Severity, len(opts.SeverityOverrides))
+	for rule, level := range opts.SeverityOverrides {
+		scoped.SeverityOverrides[rule] = level
+	}
+
+	config.applyModuleSettings(&scoped)
+	return &scoped, nil
+}
+
+func cloneIgnoredAttributes(ignored map[string][]string) map[string][]string {
+	cloned := make(map[string][]string, len(ignored))
+	for key, paths := range ignored {
+		cloned[key] = slices.Clone(paths)
+	}
+	return cloned
+}
+
+// validateSeverityOverride rejects overrides for rules diffy does not know and levels it cannot report.
+func validateSeverityOverride(rule string, level Severity) error {
+	if _, ok := FindRule(rule); !ok {
+		return fmt.Errorf("unknown rule %q in severity", rule)
+	}
+	if !isSeverity(level) {
+		return fmt.Errorf("invalid severity %q for rule %s", level, rule)
+	}
+	return nil
+}
+
+func isSeverity(level Severity) bool {
+	switch level {
+	case SeverityError, SeverityWarning, SeverityNote:
+		return true
+	}
+	return false
+}
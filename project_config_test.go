@@ -0,0 +1,358 @@
+package diffy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testProjectConfig = `
+exclude {
+  resources    = ["azurerm_role_assignment"]
+  data_sources = ["azurerm_client_config"]
+  attributes   = ["*.tags"]
+}
+
+resource "azurerm_storage_account" {
+  ignore = ["min_tls_version", "network_rules.bypass"]
+}
+
+data "azurerm_key_vault" {
+  ignore = ["tags"]
+}
+
+suppress "azurerm_key_vault.network_acls.ip_rules" {
+  owner   = "security"
+  reason  = "firewall rules managed centrally"
+  expires = "2026-12-31"
+}
+
+severity = {
+  missing-optional-attribute = "note"
+}
+
+output {
+  format = "sarif"
+  file   = "diffy.sarif"
+}
+
+github {
+  actions = true
+  owner   = "acme"
+  repo    = "infra"
+}
+`
+
+func TestLoadProjectConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ProjectConfigFile)
+	writeTestFile(t, path, testProjectConfig)
+
+	config, err := LoadProjectConfig(path)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig() error = %v", err)
+	}
+
+	opts := &SchemaValidatorOptions{Format: FormatText}
+	config.Apply(opts)
+
+	want := &SchemaValidatorOptions{
+		Format:              FormatSARIF,
+		OutputFile:          "diffy.sarif",
+		GitHubActions:       true,
+		GitHubOwner:         "acme",
+		GitHubRepo:          "infra",
+		ExcludedResources:   []string{"azurerm_role_assignment"},
+		ExcludedDataSources: []string{"azurerm_client_config"},
+		ExcludedAttributes:  []string{"*.tags"},
+		IgnoredAttributes: map[string][]string{
+			"azurerm_storage_account": {"min_tls_version", "network_rules.bypass"},
+			"data.azurerm_key_vault":  {"tags"},
+		},
+		Suppressions: []AttributeSuppression{{
+			Pattern: "azurerm_key_vault.network_acls.ip_rules",
+			SuppressionMetadata: SuppressionMetadata{
+				Owner:   "security",
+				Reason:  "firewall rules managed centrally",
+				Expires: "2026-12-31",
+			},
+		}},
+		SeverityOverrides: map[string]Severity{RuleMissingOptionalAttribute: SeverityNote},
+	}
+	if diff := cmp.Diff(want, opts); diff != "" {
+		t.Fatalf("Apply() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadProjectConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "syntax", content: `exclude {`, wantErr: "failed to parse configuration"},
+		{name: "unknown block", content: `lint {}`, wantErr: "invalid configuration"},
+		{name: "unknown rule", content: `severity = { nope = "error" }`, wantErr: `unknown rule "nope"`},
+		{name: "unknown severity", content: `severity = { missing-required-block = "fatal" }`, wantErr: `invalid severity "fatal"`},
+		{name: "suppression pattern", content: `suppress "tags" {}`, wantErr: `invalid suppression pattern "tags"`},
+		{name: "suppression expiry", content: `suppress "*.tags" { expires = "next year" }`, wantErr: "invalid expires date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ProjectConfigFile)
+			writeTestFile(t, path, tt.content)
+
+			if _, err := LoadProjectConfig(path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadProjectConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveOptionsPrecedence(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, ProjectConfigFile), testProjectConfig)
+	t.Setenv("TERRAFORM_ROOT", "")
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("EXCLUDED_RESOURCES", "azurerm_monitor_diagnostic_setting")
+
+	opts, err := resolveOptions(
+		WithTerraformRoot(root),
+		WithOutputFormat(FormatJSON),
+		WithExcludedResources("azurerm_management_lock"),
+	)
+	if err != nil {
+		t.Fatalf("resolveOptions() error = %v", err)
+	}
+
+	if opts.Format != FormatJSON {
+		t.Errorf("options should override the config format, got %q", opts.Format)
+	}
+	if opts.OutputFile != "diffy.sarif" || !opts.GitHubActions {
+		t.Errorf("config settings should apply when no option overrides them, got %+v", opts)
+	}
+	if opts.ConfigFile != filepath.Join(root, ProjectConfigFile) {
+		t.Errorf("ConfigFile = %q", opts.ConfigFile)
+	}
+
+	wantExcluded := []string{"azurerm_role_assignment", "azurerm_management_lock", "azurerm_monitor_diagnostic_setting"}
+	if diff := cmp.Diff(wantExcluded, opts.ExcludedResources); diff != "" {
+		t.Errorf("exclusions should accumulate config, options then env (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveOptionsExplicitConfigFile(t *testing.T) {
+	t.Setenv("TERRAFORM_ROOT", "")
+	root := t.TempDir()
+
+	if _, err := resolveOptions(WithTerraformRoot(root), WithConfigFile(filepath.Join(root, "missing.hcl"))); err == nil {
+		t.Fatal("an explicit config file that does not exist should fail")
+	}
+
+	opts, err := resolveOptions(WithTerraformRoot(root))
+	if err != nil {
+		t.Fatalf("a missing .diffy.hcl should be fine, got %v", err)
+	}
+	if opts.ConfigFile != "" {
+		t.Fatalf("ConfigFile = %q, want empty", opts.ConfigFile)
+	}
+}
+
+func TestModuleOptionsScopesSubmoduleConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ProjectConfigFile), `
+exclude {
+  resources = ["azurerm_subnet"]
+}
+
+resource "azurerm_subnet" {
+  ignore = ["delegation"]
+}
+
+output {
+  format = "json"
+}
+`)
+
+	opts := &SchemaValidatorOptions{
+		Format:            FormatText,
+		ExcludedResources: []string{"azurerm_role_assignment"},
+		SeverityOverrides: map[string]Severity{RuleMissingRequiredBlock: SeverityWarning},
+	}
+
+	scoped, err := moduleOptions(opts, dir)
+	if err != nil {
+		t.Fatalf("moduleOptions() error = %v", err)
+	}
+
+	if !slices.Equal(scoped.ExcludedResources, []string{"azurerm_role_assignment", "azurerm_subnet"}) {
+		t.Errorf("submodule exclusions should extend the project ones, got %v", scoped.ExcludedResources)
+	}
+	if len(opts.ExcludedResources) != 1 || opts.IgnoredAttributes != nil {
+		t.Errorf("project options should not be modified, got %+v", opts)
+	}
+	if scoped.Format != FormatText {
+		t.Errorf("submodule configuration should not change the output format, got %q", scoped.Format)
+	}
+	if scoped.SeverityOverrides[RuleMissingRequiredBlock] != SeverityWarning {
+		t.Errorf("project severity overrides should carry over, got %v", scoped.SeverityOverrides)
+	}
+
+	unchanged, err := moduleOptions(opts, t.TempDir())
+	if err != nil || unchanged != opts {
+		t.Fatalf("a submodule without configuration should reuse the project options, got %v, %v", unchanged, err)
+	}
+}
+
+func TestValidateProjectAppliesProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.tf"), `resource "azurerm_storage_account" "sa" {}`)
+	writeTestFile(t, filepath.Join(root, ProjectConfigFile), `
+resource "azurerm_storage_account" {
+  ignore = ["min_tls_version"]
+}
+
+severity = {
+  missing-required-attribute = "warning"
+}
+`)
+
+	sub := filepath.Join(root, "modules", "logs")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(sub, "main.tf"), `resource "azurerm_storage_account" "logs" {}`)
+	writeTestFile(t, filepath.Join(sub, ProjectConfigFile), `
+resource "azurerm_storage_account" {
+  ignore = ["name"]
+}
+`)
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	runner := &validateStubRunner{schema: &TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				ResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_storage_account": {Block: &SchemaBlock{
+						Attributes: map[string]*SchemaAttribute{
+							"name":            {Required: true},
+							"min_tls_version": {Optional: true},
+						},
+					}},
+				},
+			},
+		},
+	}}
+	t.Setenv("TERRAFORM_ROOT", "")
+
+	opts, err := resolveOptions(
+		WithTerraformRoot(root),
+		WithTerraformRunner(runner),
+		WithParser(&configStubParser{DefaultHCLParser: NewHCLParser(), source: source}),
+		func(opts *SchemaValidatorOptions) { opts.Silent = true },
+	)
+	if err != nil {
+		t.Fatalf("resolveOptions() error = %v", err)
+	}
+
+	report, err := validateProject(opts)
+	if err != nil {
+		t.Fatalf("validateProject() error = %v", err)
+	}
+
+	if len(report.Findings) != 1 {
+		t.Fatalf("expected only the root name finding, got %+v", report.Findings)
+	}
+	finding := report.Findings[0]
+	if finding.Address != "azurerm_storage_account.sa" || finding.Name != "name" || finding.Level() != SeverityWarning {
+		t.Fatalf("unexpected finding %+v (level %s)", finding, finding.Level())
+	}
+}
+
+// configStubParser parses real files but supplies provider requirements without a terraform block.
+type configStubParser struct {
+	*DefaultHCLParser
+	source string
+}
+
+func (p *configStubParser) ParseProviderRequirements(_ context.Context, _ string) (map[string]ProviderConfig, error) {
+	return map[string]ProviderConfig{"azurerm": {Source: p.source}}, nil
+}
+
+func TestValidateProjectReportsExpiredConfigSuppressions(t *testing.T) {
+	setNow(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.tf"), `resource "azurerm_key_vault" "kv" {
+  network_acls {}
+}`)
+	writeTestFile(t, filepath.Join(root, ProjectConfigFile), `
+suppress "azurerm_key_vault.network_acls" {
+  owner   = "security"
+  expires = "2026-05-31"
+}
+
+suppress "azurerm_key_vault.sku_name" {
+  expires = "2026-12-31"
+}
+
+severity = {
+  expired-suppression = "note"
+}
+`)
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	runner := &validateStubRunner{schema: &TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				ResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_key_vault": {Block: &SchemaBlock{
+						Attributes: map[string]*SchemaAttribute{
+							"sku_name": {Required: true},
+						},
+						BlockTypes: map[string]*SchemaBlockType{
+							"network_acls": {Nesting: "list", Block: &SchemaBlock{
+								Attributes: map[string]*SchemaAttribute{
+									"bypass": {Required: true},
+								},
+							}},
+						},
+					}},
+				},
+			},
+		},
+	}}
+	t.Setenv("TERRAFORM_ROOT", "")
+
+	opts, err := resolveOptions(
+		WithTerraformRoot(root),
+		WithTerraformRunner(runner),
+		WithParser(&configStubParser{DefaultHCLParser: NewHCLParser(), source: source}),
+	)
+	if err != nil {
+		t.Fatalf("resolveOptions() error = %v", err)
+	}
+
+	report, err := validateProject(opts)
+	if err != nil {
+		t.Fatalf("validateProject() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, string(f.Level())+" "+f.RuleID()+" "+f.Path+"."+f.Name+" "+f.Detail)
+	}
+	want := []string{
+		"error missing-required-attribute root.network_acls.bypass ",
+		"note expired-suppression root.network_acls.bypass expired on 2026-05-31, owner security",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,91 @@
+package diffy
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// providerReference reads the provider meta-argument of a resource, data source or ephemeral resource,
+// such as azurerm or azurerm.secondary, and returns the provider configuration address it selects.
+func providerReference(data BlockData) (string, bool) {
+	attribute, ok := data.Attributes["provider"]
+	if !ok {
+		return "", false
+	}
+
+	traversal, diags := hcl.AbsTraversalForExpr(attribute.Expr)
+	if diags.HasErrors() || len(traversal) == 0 || len(traversal) > 2 {
+		return "", false
+	}
+
+	address := traversal.RootName()
+	if len(traversal) == 2 {
+		alias, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return "", false
+		}
+		address += "." + alias.Name
+	}
+	return address, true
+}
+
+// providerAliases collects every aliased provider configuration address a module declares, such as
+// azurerm.secondary, from provider blocks and from configuration_aliases in required_providers.
+func providerAliases(providers map[string]ProviderConfig, providerBlocks []ParsedProvider) map[string]bool {
+	aliases := make(map[string]bool)
+	for name, cfg := range providers {
+		for _, alias := range cfg.ConfigurationAliases {
+			aliases[name+"."+alias] = true
+		}
+	}
+	for _, block := range providerBlocks {
+		if block.Alias != "" {
+			aliases[block.Name+"."+block.Alias] = true
+		}
+	}
+	return aliases
+}
+
+// proxyProvider reports whether a provider block only declares an alias. Child modules use such blocks to
+// name a configuration their caller passes in, so it has no arguments of its own to validate.
+func proxyProvider(block ParsedProvider) bool {
+	return block.Alias != "" &&
+		len(block.Data.Properties) == 0 &&
+		len(block.Data.StaticBlocks) == 0 &&
+		len(block.Data.DynamicBlocks) == 0
+}
+
+// undeclaredAlias returns the provider configuration an entity selects when it names an alias the module
+// neither configures nor expects from its caller; Terraform rejects such a reference.
+func (validator *DefaultSchemaValidator) undeclaredAlias(data BlockData) (string, bool) {
+	reference, ok := providerReference(data)
+	if !ok || !strings.Contains(reference, ".") || validator.aliases[reference] {
+		return "", false
+	}
+	return reference, true
+}
+
+// providerName resolves the local provider name an entity is configured with. The provider meta-argument
+// takes precedence, aliased or not; the type prefix is the fallback Terraform uses when no provider is
+// selected explicitly.
+func (validator *DefaultSchemaValidator) providerName(entityType string, data BlockData, kind EntityKind, providers map[string]ProviderConfig) string {
+	if kind == EntityKindProvider {
+		return entityType
+	}
+
+	prefix := strings.SplitN(entityType, "_", 2)[0]
+
+	reference, ok := providerReference(data)
+	if !ok {
+		return prefix
+	}
+
+	name := strings.SplitN(reference, ".", 2)[0]
+	if _, ok := providers[name]; ok {
+		return name
+	}
+
+	validator.logger.Logf("Provider %s used by %s is not in required_providers, falling back to %s", reference, entityType, prefix)
+	return prefix
+}
@@ -0,0 +1,121 @@
+package diffy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func providerBlockData(t *testing.T, src string) BlockData {
+	t.Helper()
+	file, diags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("ParseConfig() error = %v", diags)
+	}
+	return ParseSyntaxBody(file.Body.(*hclsyntax.Body)).Data
+}
+
+func TestProviderReference(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		want   string
+		wantOK bool
+	}{
+		{name: "no meta-argument", src: `name = "kv"`},
+		{name: "default configuration", src: `provider = azurerm`, want: "azurerm", wantOK: true},
+		{name: "aliased configuration", src: `provider = az.secondary`, want: "az.secondary", wantOK: true},
+		{name: "not a reference", src: `provider = "azurerm"`},
+		{name: "too deep", src: `provider = azurerm.a.b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := providerReference(providerBlockData(t, tt.src))
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("providerReference() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestProviderAliases(t *testing.T) {
+	providers := map[string]ProviderConfig{
+		"azurerm": {Source: "registry.terraform.io/hashicorp/azurerm", ConfigurationAliases: []string{"hub"}},
+		"az":      {Source: "registry.terraform.io/hashicorp/azurerm"},
+	}
+	providerBlocks := []ParsedProvider{
+		{Name: "az"},
+		{Name: "az", Alias: "secondary"},
+	}
+
+	want := map[string]bool{
+		"azurerm.hub":  true,
+		"az.secondary": true,
+	}
+	if diff := cmp.Diff(want, providerAliases(providers, providerBlocks)); diff != "" {
+		t.Errorf("providerAliases() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUndeclaredAlias(t *testing.T) {
+	validator := NewSchemaValidator(&SimpleLogger{})
+	validator.aliases = map[string]bool{"az.secondary": true}
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "no provider", src: `name = "kv"`},
+		{name: "default configuration", src: `provider = az`},
+		{name: "declared alias", src: `provider = az.secondary`},
+		{name: "undeclared alias", src: `provider = az.other`, want: "az.other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, undeclared := validator.undeclaredAlias(providerBlockData(t, tt.src))
+			if got != tt.want || undeclared != (tt.want != "") {
+				t.Errorf("undeclaredAlias() = %q, %v, want %q", got, undeclared, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderName(t *testing.T) {
+	providers := map[string]ProviderConfig{
+		"azurerm": {Source: "registry.terraform.io/hashicorp/azurerm"},
+		"az":      {Source: "registry.terraform.io/hashicorp/azurerm"},
+	}
+
+	validator := NewSchemaValidator(&SimpleLogger{})
+
+	tests := []struct {
+		name       string
+		entityType string
+		src        string
+		kind       EntityKind
+		want       string
+	}{
+		{name: "type prefix", entityType: "azurerm_key_vault", src: `name = "kv"`, want: "azurerm"},
+		{name: "local name differs from prefix", entityType: "azurerm_key_vault", src: `provider = az`, want: "az"},
+		{name: "alias", entityType: "azurerm_key_vault", src: `provider = az.secondary`, want: "az"},
+		{name: "unknown local name falls back to prefix", entityType: "azurerm_key_vault", src: `provider = missing.secondary`, want: "azurerm"},
+		{name: "provider block", entityType: "az", src: `features {}`, kind: EntityKindProvider, want: "az"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := tt.kind
+			if kind == "" {
+				kind = EntityKindResource
+			}
+			if got := validator.providerName(tt.entityType, providerBlockData(t, tt.src), kind, providers); got != tt.want {
+				t.Errorf("providerName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
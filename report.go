@@ -0,0 +1,271 @@
+// Package diffy provides report rendering for validation findings
+package diffy
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+const (
+	FormatText       = "text"
+	FormatJSON       = "json"
+	FormatSARIF      = "sarif"
+	FormatJUnit      = "junit"
+	FormatGitLab     = "gitlab"
+	FormatGitHub     = "github"
+	FormatHTML       = "html"
+	FormatCheckstyle = "checkstyle"
+)
+
+const (
+	ModuleStatusPassed = "passed"
+	ModuleStatusFailed = "failed"
+	ModuleStatusError  = "error"
+)
+
+const (
+	SkipReasonExcluded         = "excluded"
+	SkipReasonNoProviderConfig = "no_provider_config"
+	SkipReasonNoProviderSchema = "no_provider_schema"
+	SkipReasonNoSchema         = "no_schema"
+	SkipReasonUndeclaredAlias  = "undeclared_provider_alias"
+)
+
+type Report struct {
+	Root      string
+	StartedAt time.Time
+	Duration  time.Duration
+	Options   ReportOptions
+	Modules   []ModuleReport
+	Findings  []ValidationFinding
+	Skipped   []SkippedEntity
+	Baseline  *BaselineResult
+}
+
+// ReportOptions is the subset of SchemaValidatorOptions that is safe to publish in reports.
+type ReportOptions struct {
+	TerraformRoot       string
+	ExcludedResources   []string
+	ExcludedDataSources []string
+	ExcludedEphemeral   []string
+	ExcludedAttributes  []string
+	Suppressions        []AttributeSuppression
+	CreateGitHubIssue   bool
+	GitHubOwner         string
+	GitHubRepo          string
+	Format              string
+	OutputFile          string
+	BaselineFile        string
+	ConfigFile          string
+	SeverityOverrides   map[string]Severity
+}
+
+type ModuleReport struct {
+	Name     string
+	Path     string
+	Status   string
+	Error    string
+	Duration time.Duration
+	Entities []EntityReport
+	Findings []ValidationFinding
+	Skipped  []SkippedEntity
+}
+
+type EntityReport struct {
+	Address        string
+	ResourceType   string
+	Name           string
+	IsDataSource   bool
+	IsEphemeral    bool
+	IsProvider     bool
+	ProviderSource string
+	Range          hcl.Range
+	Findings       int
+	Schema         *SchemaBlock
+	Data           BlockData
+	// Exclusions are the attribute exclusions, ignores and configured suppressions in effect for the entity.
+	Exclusions AttributeExclusions
+}
+
+type SkippedEntity struct {
+	Address       string
+	ResourceType  string
+	Name          string
+	IsDataSource  bool
+	IsEphemeral   bool
+	IsProvider    bool
+	SubmoduleName string
+	Reason        string
+}
+
+var reportFormatters = map[string]ReportFormatter{
+	FormatText:       &TextFormatter{},
+	FormatJSON:       &JSONFormatter{},
+	FormatSARIF:      &SARIFFormatter{},
+	FormatJUnit:      &JUnitFormatter{},
+	FormatGitLab:     &GitLabFormatter{},
+	FormatGitHub:     &GitHubActionsFormatter{},
+	FormatHTML:       &HTMLFormatter{},
+	FormatCheckstyle: &CheckstyleFormatter{},
+}
+
+func newReportOptions(opts *SchemaValidatorOptions) ReportOptions {
+	return ReportOptions{
+		TerraformRoot:       opts.TerraformRoot,
+		ExcludedResources:   opts.ExcludedResources,
+		ExcludedDataSources: opts.ExcludedDataSources,
+		ExcludedEphemeral:   opts.ExcludedEphemeral,
+		ExcludedAttributes:  opts.ExcludedAttributes,
+		Suppressions:        opts.Suppressions,
+		CreateGitHubIssue:   opts.CreateGitHubIssue,
+		GitHubOwner:         opts.GitHubOwner,
+		GitHubRepo:          opts.GitHubRepo,
+		Format:              opts.Format,
+		OutputFile:          opts.OutputFile,
+		BaselineFile:        opts.BaselineFile,
+		ConfigFile:          opts.ConfigFile,
+		SeverityOverrides:   opts.SeverityOverrides,
+	}
+}
+
+// DisplayName returns the submodule name, or "root" for the Terraform root.
+func (module ModuleReport) DisplayName() string {
+	if module.Name == "" {
+		return "root"
+	}
+	return module.Name
+}
+
+// reassignFindings hands the report's findings back to their modules and entities, so per-module status
+// and counts agree with the summary after findings were filtered, for example by a baseline.
+func (report *Report) reassignFindings() {
+	for i := range report.Modules {
+		module := &report.Modules[i]
+
+		module.Findings = nil
+		for _, finding := range report.Findings {
+			if finding.SubmoduleName == module.Name {
+				module.Findings = append(module.Findings, finding)
+			}
+		}
+
+		for j := range module.Entities {
+			entity := &module.Entities[j]
+			entity.Findings = 0
+			for _, finding := range module.Findings {
+				if finding.Address == entity.Address {
+					entity.Findings++
+				}
+			}
+		}
+
+		if module.Status != ModuleStatusError {
+			module.Status = moduleStatus(module.Findings)
+		}
+	}
+}
+
+// ErroredModules returns the modules that could not be validated, for example because parsing or terraform init failed.
+func (report *Report) ErroredModules() []ModuleReport {
+	var errored []ModuleReport
+	for _, module := range report.Modules {
+		if module.Status == ModuleStatusError {
+			errored = append(errored, module)
+		}
+	}
+	return errored
+}
+
+// Failed reports whether any finding fails the run; notes are informational and leave it passing.
+func (report *Report) Failed() bool {
+	return slices.ContainsFunc(report.Findings, ValidationFinding.fails)
+}
+
+func SupportedFormats() []string {
+	formats := make([]string, 0, len(reportFormatters))
+	for name := range reportFormatters {
+		formats = append(formats, name)
+	}
+	slices.Sort(formats)
+	return formats
+}
+
+func NewReportFormatter(format string) (ReportFormatter, error) {
+	if format == "" {
+		format = FormatText
+	}
+
+	formatter, ok := reportFormatters[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported output format %q (supported: %s)", format, strings.Join(SupportedFormats(), ", "))
+	}
+	return formatter, nil
+}
+
+func WriteReport(w io.Writer, format string, report *Report) error {
+	formatter, err := NewReportFormatter(format)
+	if err != nil {
+		return err
+	}
+	return formatter.Format(w, report)
+}
+
+type TextFormatter struct{}
+
+func (f *TextFormatter) Format(w io.Writer, report *Report) error {
+	var b strings.Builder
+
+	if len(report.Findings) == 0 {
+		b.WriteString("No validation findings.\n")
+	} else {
+		fmt.Fprintf(&b, "Found %d issues:\n", len(report.Findings))
+		for _, finding := range report.Findings {
+			b.WriteString(FormatFinding(finding) + "\n")
+			if explanation := finding.Explanation(); explanation != "" {
+				b.WriteString("    " + explanation + "\n")
+			}
+		}
+	}
+
+	for _, module := range report.ErroredModules() {
+		fmt.Fprintf(&b, "Module %s could not be validated: %s\n", module.DisplayName(), module.Error)
+	}
+
+	if baseline := report.Baseline; baseline != nil {
+		fmt.Fprintf(&b, "Baseline %s: %d known findings suppressed, %d entries fixed, %d expired\n",
+			baseline.File, len(baseline.Matched), len(baseline.Fixed), len(baseline.Expired))
+		for _, entry := range baseline.Fixed {
+			fmt.Fprintf(&b, "  fixed, can be pruned: %s\n", formatBaselineEntry(entry))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatBaselineEntry(entry BaselineEntry) string {
+	target := entry.Name
+	if entry.Path != "" && entry.Path != "root" {
+		target = entry.Path + "." + entry.Name
+	}
+	return fmt.Sprintf("%s %s (%s)", entry.Address, target, entry.Rule)
+}
+
+// moduleMainFile points findings without a source range at the main.tf of their module, for report formats
+// such as GitLab Code Quality and Checkstyle that only attach entries to files.
+func moduleMainFile(report *Report, submoduleName string) string {
+	dir := report.Root
+	for _, module := range report.Modules {
+		if module.Name == submoduleName && module.Path != "" {
+			dir = module.Path
+			break
+		}
+	}
+	return filepath.Join(dir, "main.tf")
+}
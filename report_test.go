@@ -0,0 +1,124 @@
+package diffy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewReportFormatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{name: "default", format: ""},
+		{name: "text", format: "text"},
+		{name: "case insensitive", format: "TEXT"},
+		{name: "unknown", format: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := NewReportFormatter(tt.format)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "unsupported output format") {
+					t.Fatalf("expected unsupported format error, got %v", err)
+				}
+				return
+			}
+			if err != nil || formatter == nil {
+				t.Fatalf("NewReportFormatter(%q) = %v, %v", tt.format, formatter, err)
+			}
+		})
+	}
+}
+
+func TestTextFormatter(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatText, &Report{}); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+	if got := out.String(); got != "No validation findings.\n" {
+		t.Fatalf("empty report = %q", got)
+	}
+
+	out.Reset()
+	report := &Report{Findings: []ValidationFinding{
+		{ResourceType: "azurerm_resource_group", Path: "root", Name: "location", Required: true},
+	}}
+	if err := WriteReport(&out, FormatText, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	want := "Found 1 issues:\n" + FormatFinding(report.Findings[0]) + "\n"
+	if got := out.String(); got != want {
+		t.Fatalf("text report = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	report.Findings[0].Description = "The Azure region where\nthe resource group should exist."
+	if err := WriteReport(&out, FormatText, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	want = "Found 1 issues:\n" + FormatFinding(report.Findings[0]) + "\n    The Azure region where the resource group should exist.\n"
+	if got := out.String(); got != want {
+		t.Fatalf("text report = %q, want %q", got, want)
+	}
+}
+
+func TestTextFormatterReportsErroredModules(t *testing.T) {
+	report := &Report{Modules: []ModuleReport{
+		{Status: ModuleStatusPassed},
+		{Name: "broken", Status: ModuleStatusError, Error: "failed to parse"},
+	}}
+
+	if errored := report.ErroredModules(); len(errored) != 1 || errored[0].Name != "broken" {
+		t.Fatalf("ErroredModules() = %+v, want the broken module", errored)
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatText, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+	want := "No validation findings.\nModule broken could not be validated: failed to parse\n"
+	if got := out.String(); got != want {
+		t.Fatalf("text report = %q, want %q", got, want)
+	}
+}
+
+func TestReportFailedIgnoresNotes(t *testing.T) {
+	notes := []ValidationFinding{
+		{Kind: FindingKindUnknownCardinality, IsBlock: true, Name: "identity"},
+		{Name: "tags", Severity: SeverityNote},
+	}
+	report := &Report{Findings: notes}
+	if report.Failed() {
+		t.Fatal("notes alone should not fail the report")
+	}
+	if status := moduleStatus(notes); status != ModuleStatusPassed {
+		t.Fatalf("moduleStatus() = %q, want %q", status, ModuleStatusPassed)
+	}
+
+	report.Findings = append(report.Findings, ValidationFinding{Name: "location", Required: true})
+	if !report.Failed() {
+		t.Fatal("a missing required attribute should fail the report")
+	}
+	if status := moduleStatus(report.Findings); status != ModuleStatusFailed {
+		t.Fatalf("moduleStatus() = %q, want %q", status, ModuleStatusFailed)
+	}
+}
+
+func TestSupportedFormatsIncludesText(t *testing.T) {
+	formats := SupportedFormats()
+	found := false
+	for _, format := range formats {
+		if format == FormatText {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("SupportedFormats() = %v, should include %q", formats, FormatText)
+	}
+}
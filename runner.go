@@ -3,7 +3,9 @@ package diffy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -85,13 +87,27 @@ func ValidateTerraformSchemaInDirectoryWithOptions(logger Logger, dir, submodule
 	parser := NewHCLParser()
 	runner := NewTerraformRunner()
 
-	defer cleanupTerraformArtifacts(dir)
+	defer trackTerraformArtifacts(dir)()
 
 	return ValidateTerraformSchemaWithOptions(logger, dir, submoduleName, parser, runner, excludedResources, excludedDataSources)
 }
 
-func cleanupTerraformArtifacts(dir string) {
-	os.RemoveAll(filepath.Join(dir, ".terraform"))
-	os.Remove(filepath.Join(dir, "terraform.tfstate"))
-	os.Remove(filepath.Join(dir, ".terraform.lock.hcl"))
+var terraformArtifacts = []string{".terraform", "terraform.tfstate", ".terraform.lock.hcl"}
+
+// trackTerraformArtifacts records which Terraform artifacts are missing from dir and returns a cleanup that
+// removes only those, so an initialized working directory and a committed lock file are left as they were.
+func trackTerraformArtifacts(dir string) func() {
+	var created []string
+	for _, name := range terraformArtifacts {
+		path := filepath.Join(dir, name)
+		if _, err := os.Lstat(path); errors.Is(err, fs.ErrNotExist) {
+			created = append(created, path)
+		}
+	}
+
+	return func() {
+		for _, path := range created {
+			os.RemoveAll(path)
+		}
+	}
 }
@@ -193,3 +193,29 @@ func readFile(t *testing.T, path string) string {
 	}
 	return string(data)
 }
+
+func TestTrackTerraformArtifactsKeepsExistingArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, ".terraform.lock.hcl")
+	if err := os.WriteFile(lockFile, []byte("# committed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup := trackTerraformArtifacts(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, ".terraform", "providers"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lockFile, []byte("# updated by init\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup()
+
+	if _, err := os.Stat(lockFile); err != nil {
+		t.Fatalf("existing lock file should be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".terraform")); !os.IsNotExist(err) {
+		t.Fatalf(".terraform created during the run should be removed, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package diffy
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+const (
+	sarifVersion         = "2.1.0"
+	sarifSchema          = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifSourceRoot      = "SRCROOT"
+	sarifFingerprintName = "diffyFinding/v1"
+	toolInformationURI   = "https://github.com/dkooll/diffy"
+)
+
+type SARIFFormatter struct{}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func (f *SARIFFormatter) Format(w io.Writer, report *Report) error {
+	rules := Rules()
+	ruleIndex := make(map[string]int, len(rules))
+
+	driver := sarifDriver{
+		Name:           "diffy",
+		InformationURI: toolInformationURI,
+		Rules:          make([]sarifRule, 0, len(rules)),
+	}
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Name,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: string(rule.Severity)},
+		})
+	}
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: driver},
+		Results: []sarifResult{},
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
+			sarifSourceRoot: {URI: fileURI(wd) + "/"},
+		}
+	}
+
+	for _, finding := range report.Findings {
+		message := FormatFinding(finding)
+		if explanation := finding.Explanation(); explanation != "" {
+			message += "\n\n" + explanation
+		}
+
+		result := sarifResult{
+			RuleID:    finding.RuleID(),
+			RuleIndex: ruleIndex[finding.RuleID()],
+			Level:     string(finding.Level()),
+			Message:   sarifMessage{Text: message},
+			PartialFingerprints: map[string]string{
+				sarifFingerprintName: finding.Fingerprint(),
+			},
+		}
+
+		location := sarifLocation{
+			PhysicalLocation: newSARIFPhysicalLocation(finding.Range),
+		}
+		if finding.Address != "" {
+			location.LogicalLocations = []sarifLogicalLocation{
+				{FullyQualifiedName: finding.Address, Kind: "resource"},
+			}
+		}
+		if location.PhysicalLocation != nil || location.LogicalLocations != nil {
+			result.Locations = []sarifLocation{location}
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func newSARIFPhysicalLocation(r hcl.Range) *sarifPhysicalLocation {
+	if r.Filename == "" {
+		return nil
+	}
+
+	artifact := sarifArtifactLocation{URI: displayPath(r.Filename), URIBaseID: sarifSourceRoot}
+	if filepath.IsAbs(artifact.URI) {
+		artifact = sarifArtifactLocation{URI: fileURI(r.Filename)}
+	}
+
+	return &sarifPhysicalLocation{
+		ArtifactLocation: artifact,
+		Region: &sarifRegion{
+			StartLine:   r.Start.Line,
+			StartColumn: r.Start.Column,
+			EndLine:     r.End.Line,
+			EndColumn:   r.End.Column,
+		},
+	}
+}
+
+func fileURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
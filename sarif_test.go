@@ -0,0 +1,116 @@
+package diffy
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestSARIFFormatter(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+
+	finding := ValidationFinding{
+		ResourceType: "azurerm_storage_account",
+		Address:      "azurerm_storage_account.sa",
+		Path:         "root",
+		Name:         "min_tls_version",
+		Range: hcl.Range{
+			Filename: filepath.Join(wd, "examples", "module", "main.tf"),
+			Start:    hcl.Pos{Line: 3, Column: 1},
+			End:      hcl.Pos{Line: 9, Column: 2},
+		},
+	}
+	report := &Report{Findings: []ValidationFinding{
+		finding,
+		{ResourceType: "azurerm_subnet", Path: "root", Name: "name", Required: true},
+	}}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatSARIF, report); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation *struct {
+						ArtifactLocation struct {
+							URI       string `json:"uri"`
+							URIBaseID string `json:"uriBaseId"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+							EndLine   int `json:"endLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "diffy" {
+		t.Fatalf("unexpected SARIF envelope: %+v", log)
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(Rules()) {
+		t.Fatalf("expected %d rules, got %d", len(Rules()), len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.RuleID != RuleMissingOptionalAttribute || first.Level != "warning" {
+		t.Errorf("unexpected rule/level: %s/%s", first.RuleID, first.Level)
+	}
+	if run.Tool.Driver.Rules[first.RuleIndex].ID != first.RuleID {
+		t.Errorf("ruleIndex %d does not point at %s", first.RuleIndex, first.RuleID)
+	}
+	if first.PartialFingerprints[sarifFingerprintName] != finding.Fingerprint() {
+		t.Errorf("unexpected fingerprint: %v", first.PartialFingerprints)
+	}
+
+	physical := first.Locations[0].PhysicalLocation
+	if physical == nil || physical.ArtifactLocation.URI != "examples/module/main.tf" || physical.ArtifactLocation.URIBaseID != sarifSourceRoot {
+		t.Fatalf("unexpected physical location: %+v", physical)
+	}
+	if physical.Region.StartLine != 3 || physical.Region.EndLine != 9 {
+		t.Errorf("unexpected region: %+v", physical.Region)
+	}
+	if first.Locations[0].LogicalLocations[0].FullyQualifiedName != "azurerm_storage_account.sa" {
+		t.Errorf("unexpected logical location: %+v", first.Locations[0].LogicalLocations)
+	}
+
+	second := run.Results[1]
+	if second.Level != "error" || len(second.Locations) != 0 {
+		t.Errorf("finding without range or address should have no locations: %+v", second)
+	}
+}
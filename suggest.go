@@ -0,0 +1,86 @@
+package diffy
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// maxSuggestions caps how many alternatives a "did you mean" hint lists.
+const maxSuggestions = 3
+
+// suggestNames returns the candidates closest to name, best match first, skipping ones too different to be a typo.
+func suggestNames(name string, candidates []string) []string {
+	type scored struct {
+		name     string
+		distance int
+	}
+
+	limit := max(2, len(name)/3)
+	var matches []scored
+	for _, candidate := range candidates {
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); distance <= limit {
+			matches = append(matches, scored{name: candidate, distance: distance})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b scored) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), cmp.Compare(a.name, b.name))
+	})
+
+	var names []string
+	for _, match := range matches[:min(len(matches), maxSuggestions)] {
+		names = append(names, match.name)
+	}
+	return names
+}
+
+// schemaNames lists the block types or attributes a schema block defines, the candidates for a misspelled name.
+func schemaNames(schema *SchemaBlock, blocks bool) []string {
+	if blocks {
+		return slices.Collect(maps.Keys(schema.BlockTypes))
+	}
+	return slices.Collect(maps.Keys(schema.Attributes))
+}
+
+// didYouMean renders suggestions as a finding detail, or an empty string when there are none.
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		quoted[i] = `"` + suggestion + `"`
+	}
+
+	if len(quoted) == 1 {
+		return "did you mean " + quoted[0] + "?"
+	}
+	return "did you mean " + strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1] + "?"
+}
+
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
@@ -0,0 +1,65 @@
+package diffy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "name", b: "", want: 4},
+		{a: "name", b: "name", want: 0},
+		{a: "publc_network_access_enabled", b: "public_network_access_enabled", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "locaiton", b: "location", want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestNames(t *testing.T) {
+	candidates := []string{"location", "name", "public_network_access_enabled", "tags", "tag_names"}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "publc_network_access_enabled", want: []string{"public_network_access_enabled"}},
+		{name: "Locaiton", want: []string{"location"}},
+		{name: "tag", want: []string{"tags"}},
+		{name: "tags_names", want: []string{"tag_names"}},
+		{name: "resource_group", want: nil},
+	}
+
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, suggestNames(tt.name, candidates)); diff != "" {
+			t.Errorf("suggestNames(%q) mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	tests := []struct {
+		suggestions []string
+		want        string
+	}{
+		{suggestions: nil, want: ""},
+		{suggestions: []string{"name"}, want: `did you mean "name"?`},
+		{suggestions: []string{"name", "tags", "sku"}, want: `did you mean "name", "tags" or "sku"?`},
+	}
+
+	for _, tt := range tests {
+		if got := didYouMean(tt.suggestions); got != tt.want {
+			t.Errorf("didYouMean(%v) = %q, want %q", tt.suggestions, got, tt.want)
+		}
+	}
+}
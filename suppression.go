@@ -0,0 +1,340 @@
+package diffy
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const suppressionDirective = "diffy:ignore"
+
+const expiresLayout = "2006-01-02"
+
+// now is replaced in tests to pin expiry checks to a fixed date.
+var now = time.Now
+
+// SuppressionMetadata records who accepted a finding, why, and until which day (YYYY-MM-DD).
+type SuppressionMetadata struct {
+	Owner   string `json:"owner,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Expires string `json:"expires,omitempty"`
+}
+
+// Validate checks that Expires, when set, is a valid date.
+func (metadata SuppressionMetadata) Validate() error {
+	if metadata.Expires == "" {
+		return nil
+	}
+	if _, err := time.Parse(expiresLayout, metadata.Expires); err != nil {
+		return fmt.Errorf("invalid expires date %q, want YYYY-MM-DD", metadata.Expires)
+	}
+	return nil
+}
+
+// Expired reports whether the suppression lapsed before the given day; it stays valid through its expiry date.
+func (metadata SuppressionMetadata) Expired(at time.Time) bool {
+	return metadata.Expires != "" && at.Format(expiresLayout) > metadata.Expires
+}
+
+func (metadata SuppressionMetadata) expiredDetail() string {
+	parts := []string{"expired on " + metadata.Expires}
+	if metadata.Owner != "" {
+		parts = append(parts, "owner "+metadata.Owner)
+	}
+	if metadata.Reason != "" {
+		parts = append(parts, "reason: "+metadata.Reason)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// expiredFinding turns a finding back into a reminder that the suppression covering it has lapsed.
+func expiredFinding(finding ValidationFinding, metadata SuppressionMetadata) ValidationFinding {
+	return ValidationFinding{
+		Kind:          FindingKindExpiredSuppression,
+		ResourceType:  finding.ResourceType,
+		Address:       finding.Address,
+		Path:          finding.Path,
+		Name:          finding.Name,
+		IsBlock:       finding.IsBlock,
+		IsDataSource:  finding.IsDataSource,
+		IsEphemeral:   finding.IsEphemeral,
+		IsProvider:    finding.IsProvider,
+		SubmoduleName: finding.SubmoduleName,
+		Range:         finding.Range,
+		Detail:        metadata.expiredDetail(),
+	}
+}
+
+// Suppression accepts a single finding on the resource or nested block the comment precedes.
+type Suppression struct {
+	Name    string
+	IsBlock bool
+	SuppressionMetadata
+	Range hcl.Range
+	// Invalid explains why the comment cannot be applied, such as a malformed expires date.
+	Invalid string
+}
+
+type scopedSuppression struct {
+	Suppression
+	path   string
+	target hcl.Range
+	used   bool
+}
+
+// ParseSuppressions extracts diffy:ignore comments from a Terraform file, keyed by the line of the block they precede.
+func ParseSuppressions(src []byte, filename string) map[int][]Suppression {
+	tokens, diags := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+
+	byLine := make(map[int][]Suppression)
+	for i, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+
+		suppressions := parseSuppressionComment(string(token.Bytes), token.Range)
+		if len(suppressions) == 0 {
+			continue
+		}
+
+		if line := nextCodeLine(tokens[i+1:]); line > 0 {
+			byLine[line] = append(byLine[line], suppressions...)
+		}
+	}
+	return byLine
+}
+
+func nextCodeLine(tokens hclsyntax.Tokens) int {
+	for _, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenComment, hclsyntax.TokenNewline:
+			continue
+		case hclsyntax.TokenEOF:
+			return 0
+		default:
+			return token.Range.Start.Line
+		}
+	}
+	return 0
+}
+
+func parseSuppressionComment(text string, rng hcl.Range) []Suppression {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, "#"):
+		text = strings.TrimPrefix(text, "#")
+	case strings.HasPrefix(text, "//"):
+		text = strings.TrimPrefix(text, "//")
+	default:
+		return nil
+	}
+
+	text, ok := strings.CutPrefix(strings.TrimSpace(text), suppressionDirective)
+	if !ok {
+		return nil
+	}
+
+	var attributes, blocks []string
+	var metadata SuppressionMetadata
+	for key, value := range directiveFields(text) {
+		switch key {
+		case "attribute":
+			attributes = append(attributes, splitNames(value)...)
+		case "block":
+			blocks = append(blocks, splitNames(value)...)
+		case "reason":
+			metadata.Reason = value
+		case "owner":
+			metadata.Owner = value
+		case "expires":
+			metadata.Expires = value
+		}
+	}
+
+	var invalid string
+	if err := metadata.Validate(); err != nil {
+		invalid = err.Error()
+	}
+
+	var suppressions []Suppression
+	for _, name := range attributes {
+		suppressions = append(suppressions, Suppression{Name: name, SuppressionMetadata: metadata, Range: rng, Invalid: invalid})
+	}
+	for _, name := range blocks {
+		suppressions = append(suppressions, Suppression{Name: name, IsBlock: true, SuppressionMetadata: metadata, Range: rng, Invalid: invalid})
+	}
+	return suppressions
+}
+
+// directiveFields yields key=value pairs, where values may be double-quoted.
+func directiveFields(text string) func(yield func(string, string) bool) {
+	return func(yield func(string, string) bool) {
+		rest := strings.TrimSpace(text)
+		for rest != "" {
+			eq := strings.IndexByte(rest, '=')
+			if eq <= 0 {
+				return
+			}
+			key := strings.TrimSpace(rest[:eq])
+			rest = strings.TrimLeftFunc(rest[eq+1:], unicode.IsSpace)
+
+			var value string
+			if strings.HasPrefix(rest, `"`) {
+				quoted, err := strconv.QuotedPrefix(rest)
+				if err != nil {
+					return
+				}
+				value, _ = strconv.Unquote(quoted)
+				rest = rest[len(quoted):]
+			} else {
+				end := strings.IndexFunc(rest, unicode.IsSpace)
+				if end < 0 {
+					end = len(rest)
+				}
+				value = rest[:end]
+				rest = rest[end:]
+			}
+
+			if !yield(key, value) {
+				return
+			}
+			rest = strings.TrimSpace(rest)
+		}
+	}
+}
+
+func splitNames(value string) []string {
+	var names []string
+	for name := range strings.SplitSeq(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// AttachSuppressions assigns parsed suppressions to the block, and its nested blocks, they precede.
+func (blockData *BlockData) AttachSuppressions(byLine map[int][]Suppression) {
+	if len(byLine) == 0 {
+		return
+	}
+
+	blockData.Suppressions = byLine[blockData.Range.Start.Line]
+
+	for _, blocks := range blockData.StaticBlocks {
+		for _, blk := range blocks {
+			blk.Data.AttachSuppressions(byLine)
+		}
+	}
+	for _, dynamic := range blockData.DynamicBlocks {
+		dynamic.Data.AttachSuppressions(byLine)
+	}
+}
+
+func (blockData *BlockData) collectSuppressions(path string) []*scopedSuppression {
+	var scoped []*scopedSuppression
+	for _, suppression := range blockData.Suppressions {
+		scoped = append(scoped, &scopedSuppression{
+			Suppression: suppression,
+			path:        path,
+			target:      blockData.Range,
+		})
+	}
+
+	for name, blocks := range blockData.StaticBlocks {
+		for i, blk := range blocks {
+			blockPath := path + "." + name
+			if len(blocks) > 1 {
+				blockPath = path + "." + name + "[" + strconv.Itoa(i) + "]"
+			}
+			scoped = append(scoped, blk.Data.collectSuppressions(blockPath)...)
+		}
+	}
+	for name, dynamic := range blockData.DynamicBlocks {
+		scoped = append(scoped, dynamic.Data.collectSuppressions(path+"."+name)...)
+	}
+	return scoped
+}
+
+// suppresses reports whether an unexpired diffy:ignore comment on the block accepts the named attribute or block.
+func (blockData *BlockData) suppresses(name string, isBlock bool) bool {
+	today := now()
+	for _, suppression := range blockData.Suppressions {
+		if suppression.IsBlock == isBlock && strings.EqualFold(suppression.Name, name) && suppression.applies(today) {
+			return true
+		}
+	}
+	return false
+}
+
+// applies reports whether the suppression is well-formed and has not expired by the given day.
+func (suppression Suppression) applies(at time.Time) bool {
+	return suppression.Invalid == "" && !suppression.Expired(at)
+}
+
+func (suppression *scopedSuppression) matches(finding ValidationFinding) bool {
+	return !finding.isSuppressionFinding() &&
+		finding.Range == suppression.target &&
+		finding.IsBlock == suppression.IsBlock &&
+		strings.EqualFold(finding.Name, suppression.Name)
+}
+
+// applySuppressions drops suppressed findings and reports suppressions that matched nothing, are malformed or have expired.
+func applySuppressions(resourceType string, data *BlockData, findings []ValidationFinding) []ValidationFinding {
+	suppressions := data.collectSuppressions("root")
+	if len(suppressions) == 0 {
+		return findings
+	}
+	slices.SortFunc(suppressions, func(a, b *scopedSuppression) int {
+		return cmp.Compare(a.Range.Start.Byte, b.Range.Start.Byte)
+	})
+
+	today := now()
+	remaining := make([]ValidationFinding, 0, len(findings))
+	for _, finding := range findings {
+		suppressed := false
+		for _, suppression := range suppressions {
+			if !suppression.applies(today) || !suppression.matches(finding) {
+				continue
+			}
+			suppression.used = true
+			suppressed = true
+		}
+		if !suppressed {
+			remaining = append(remaining, finding)
+		}
+	}
+
+	for _, suppression := range suppressions {
+		if suppression.used {
+			continue
+		}
+
+		finding := ValidationFinding{
+			Kind:         FindingKindUnusedSuppression,
+			ResourceType: resourceType,
+			Path:         suppression.path,
+			Name:         suppression.Name,
+			IsBlock:      suppression.IsBlock,
+			Range:        suppression.Range,
+		}
+		switch {
+		case suppression.Invalid != "":
+			finding.Detail = suppression.Invalid
+		case suppression.Expired(today):
+			finding = expiredFinding(finding, suppression.SuppressionMetadata)
+		}
+		remaining = append(remaining, finding)
+	}
+	return remaining
+}
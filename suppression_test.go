@@ -0,0 +1,272 @@
+package diffy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	src := `# diffy:ignore attribute=public_network_access_enabled reason="private endpoints only"
+# unrelated comment
+resource "azurerm_storage_account" "sa" {
+  name = "sa"
+
+  // diffy:ignore attribute=bypass,ip_rules block=private_link_access
+  network_rules {
+    default_action = "Deny"
+  }
+}
+
+# diffy:ignore attribute=dangling
+`
+	got := ParseSuppressions([]byte(src), "main.tf")
+
+	summarize := func(line int) []string {
+		var names []string
+		for _, s := range got[line] {
+			kind := "attribute"
+			if s.IsBlock {
+				kind = "block"
+			}
+			names = append(names, kind+":"+s.Name+":"+s.Reason)
+		}
+		return names
+	}
+
+	if diff := cmp.Diff([]string{"attribute:public_network_access_enabled:private endpoints only"}, summarize(3)); diff != "" {
+		t.Errorf("resource suppressions mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"attribute:bypass:", "attribute:ip_rules:", "block:private_link_access:"}, summarize(7)); diff != "" {
+		t.Errorf("nested block suppressions mismatch (-want +got):\n%s", diff)
+	}
+	if len(got) != 2 {
+		t.Errorf("comments that precede no code should be dropped, got lines %v", got)
+	}
+	if got[3][0].Range.Start.Line != 1 {
+		t.Errorf("suppression range should point at the comment, got %+v", got[3][0].Range)
+	}
+}
+
+func TestParseSuppressionCommentIgnoresOtherComments(t *testing.T) {
+	for _, text := range []string{
+		"# just a note\n",
+		"# diffy:ignore\n",
+		"/* diffy:ignore attribute=tags */",
+		"# diffy:ignored attribute=tags\n",
+	} {
+		if got := parseSuppressionComment(text, hcl.Range{}); len(got) != 0 {
+			t.Errorf("parseSuppressionComment(%q) = %+v, want none", text, got)
+		}
+	}
+}
+
+func TestSuppressionsApplyToFindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	tfFile := filepath.Join(tmpDir, "main.tf")
+	content := `# diffy:ignore attribute=min_tls_version reason="set by policy"
+resource "azurerm_storage_account" "sa" {
+  name = "sa"
+
+  # diffy:ignore attribute=bypass
+  # diffy:ignore attribute=default_action
+  network_rules {
+    default_action = "Deny"
+  }
+}
+`
+	if err := os.WriteFile(tfFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resources, _, err := NewHCLParser().ParseMainFile(context.Background(), tfFile)
+	if err != nil {
+		t.Fatalf("ParseMainFile() error = %v", err)
+	}
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	schema := TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				ResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_storage_account": {
+						Block: &SchemaBlock{
+							Attributes: map[string]*SchemaAttribute{
+								"name":            {Required: true},
+								"min_tls_version": {Optional: true},
+								"https_only":      {Optional: true},
+							},
+							BlockTypes: map[string]*SchemaBlockType{
+								"network_rules": {
+									Nesting: "single",
+									Block: &SchemaBlock{
+										Attributes: map[string]*SchemaAttribute{
+											"default_action": {Required: true},
+											"bypass":         {Optional: true},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	validator := NewSchemaValidator(&SimpleLogger{})
+	findings := validator.ValidateResources(resources, schema, map[string]ProviderConfig{"azurerm": {Source: source}}, tmpDir, "")
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.RuleID()+" "+f.Path+" "+f.Name)
+	}
+	want := []string{
+		"missing-optional-attribute root https_only",
+		"unused-suppression root.network_rules default_action",
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, f := range findings {
+		if f.Kind == FindingKindUnusedSuppression {
+			if f.Range.Start.Line != 6 || f.Address != "azurerm_storage_account.sa" {
+				t.Errorf("unused suppression should point at its comment, got %+v", f)
+			}
+			if want := "azurerm_storage_account.sa: unused suppression for property default_action in network_rules (resource)"; !strings.HasPrefix(FormatFinding(f), want) {
+				t.Errorf("FormatFinding() = %q", FormatFinding(f))
+			}
+		}
+	}
+}
+
+func TestSuppressionMetadataExpiry(t *testing.T) {
+	day := time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expires string
+		expired bool
+	}{
+		{expires: "", expired: false},
+		{expires: "2026-03-16", expired: false},
+		{expires: "2026-03-15", expired: false},
+		{expires: "2026-03-14", expired: true},
+	}
+
+	for _, tt := range tests {
+		metadata := SuppressionMetadata{Expires: tt.expires}
+		if err := metadata.Validate(); err != nil {
+			t.Fatalf("Validate(%q) error = %v", tt.expires, err)
+		}
+		if got := metadata.Expired(day); got != tt.expired {
+			t.Errorf("Expired() with expires %q = %v, want %v", tt.expires, got, tt.expired)
+		}
+	}
+
+	if err := (SuppressionMetadata{Expires: "15/03/2026"}).Validate(); err == nil {
+		t.Error("Validate() should reject dates that are not YYYY-MM-DD")
+	}
+}
+
+func TestExpiredInlineSuppressionReportsFindingAgain(t *testing.T) {
+	setNow(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	src := `# diffy:ignore attribute=tags owner=platform reason="tagging later" expires=2026-05-31
+resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}
+`
+	tfFile := filepath.Join(t.TempDir(), "main.tf")
+	if err := os.WriteFile(tfFile, []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resources, _, err := NewHCLParser().ParseMainFile(context.Background(), tfFile)
+	if err != nil {
+		t.Fatalf("ParseMainFile() error = %v", err)
+	}
+
+	schema := &SchemaBlock{Attributes: map[string]*SchemaAttribute{
+		"name": {Required: true},
+		"tags": {Optional: true},
+	}}
+
+	data := resources[0].Data
+	var findings []ValidationFinding
+	data.Validate("azurerm_resource_group", "root", schema, nil, &findings)
+	findings = applySuppressions("azurerm_resource_group", &data, findings)
+
+	var rules []string
+	for _, f := range findings {
+		rules = append(rules, f.RuleID())
+	}
+	if diff := cmp.Diff([]string{RuleMissingOptionalAttribute, RuleExpiredSuppression}, rules); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	expired := findings[1]
+	if expired.Range.Start.Line != 1 || expired.Detail != "expired on 2026-05-31, owner platform, reason: tagging later" {
+		t.Fatalf("unexpected expired finding %+v", expired)
+	}
+	if !strings.HasSuffix(FormatFinding(expired), " - expired on 2026-05-31, owner platform, reason: tagging later") {
+		t.Fatalf("FormatFinding() should include the detail, got %q", FormatFinding(expired))
+	}
+}
+
+func TestMalformedInlineSuppressionIsReported(t *testing.T) {
+	src := `# diffy:ignore attribute=tags expires=31-05-2026
+resource "azurerm_resource_group" "rg" {
+  name = "rg"
+}
+`
+	tfFile := filepath.Join(t.TempDir(), "main.tf")
+	if err := os.WriteFile(tfFile, []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resources, _, err := NewHCLParser().ParseMainFile(context.Background(), tfFile)
+	if err != nil {
+		t.Fatalf("ParseMainFile() error = %v", err)
+	}
+
+	schema := &SchemaBlock{Attributes: map[string]*SchemaAttribute{
+		"name": {Required: true},
+		"tags": {Optional: true},
+	}}
+
+	data := resources[0].Data
+	var findings []ValidationFinding
+	data.Validate("azurerm_resource_group", "root", schema, nil, &findings)
+	findings = applySuppressions("azurerm_resource_group", &data, findings)
+
+	var rules []string
+	for _, f := range findings {
+		rules = append(rules, f.RuleID())
+	}
+	if diff := cmp.Diff([]string{RuleMissingOptionalAttribute, RuleUnusedSuppression}, rules); diff != "" {
+		t.Fatalf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	invalid := findings[1]
+	if invalid.Range.Start.Line != 1 || invalid.Name != "tags" || invalid.Detail != `invalid expires date "31-05-2026", want YYYY-MM-DD` {
+		t.Fatalf("unexpected finding for the malformed comment %+v", invalid)
+	}
+	if data.suppresses("tags", false) {
+		t.Fatal("a malformed suppression should not hide the attribute from the coverage tree")
+	}
+}
+
+func setNow(t *testing.T, at time.Time) {
+	t.Helper()
+	original := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = original })
+}
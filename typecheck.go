@@ -0,0 +1,31 @@
+package diffy
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// typeMismatch evaluates an expression without any variables or functions in scope and reports whether the
+// result can be converted to the attribute type, the way Terraform would. Expressions that depend on anything
+// only known at plan time cannot be folded to a constant and are never reported.
+func typeMismatch(want cty.Type, expr hclsyntax.Expression) (string, bool) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() {
+		return "", false
+	}
+
+	_, err := convert.Convert(value, want)
+	if err == nil {
+		return "", false
+	}
+
+	detail := fmt.Sprintf("expected %s, got %s", want.FriendlyName(), value.Type().FriendlyName())
+	if want.FriendlyName() == value.Type().FriendlyName() {
+		// Objects and collections share a friendly name, so name the offending attribute or element instead.
+		detail += ": " + err.Error()
+	}
+	return detail, true
+}
@@ -0,0 +1,135 @@
+package diffy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestTypeMismatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       cty.Type
+		expr       string
+		wantDetail string
+	}{
+		{name: "number from word", want: cty.Number, expr: `"two"`, wantDetail: "expected number, got string"},
+		{name: "number from numeric string", want: cty.Number, expr: `"2"`},
+		{name: "string from number", want: cty.String, expr: `2`},
+		{name: "bool from word", want: cty.Bool, expr: `"yes"`, wantDetail: "expected bool, got string"},
+		{name: "map from tuple", want: cty.Map(cty.String), expr: `["a"]`, wantDetail: "expected map of string, got tuple"},
+		{name: "map from object", want: cty.Map(cty.String), expr: `{ env = "prod" }`},
+		{name: "list from string", want: cty.List(cty.String), expr: `"10.0.0.0/16"`, wantDetail: "expected list of string, got string"},
+		{name: "set element mismatch", want: cty.Set(cty.Number), expr: `[1, "x"]`, wantDetail: "expected set of number, got tuple"},
+		{name: "folded arithmetic", want: cty.Number, expr: `2 * 3`},
+		{name: "folded template", want: cty.Number, expr: `"${1}0"`},
+		{name: "folded conditional", want: cty.Bool, expr: `1 > 2 ? "no" : "maybe"`, wantDetail: "expected bool, got string"},
+		{name: "object with optional attribute", want: cty.ObjectWithOptionalAttrs(map[string]cty.Type{"name": cty.String, "port": cty.Number}, []string{"port"}), expr: `{ name = "http" }`},
+		{name: "object missing attribute", want: cty.Object(map[string]cty.Type{"name": cty.String, "port": cty.Number}), expr: `{ name = "http" }`, wantDetail: `expected object, got object: attribute "port" is required`},
+		{name: "dynamic", want: cty.DynamicPseudoType, expr: `["a", 1]`},
+		{name: "null", want: cty.Number, expr: `null`},
+		{name: "variable", want: cty.Number, expr: `var.capacity`},
+		{name: "function call", want: cty.Number, expr: `max(1, 2)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(tt.expr), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("ParseExpression() error = %v", diags)
+			}
+
+			detail, ok := typeMismatch(tt.want, expr)
+			if ok != (tt.wantDetail != "") || detail != tt.wantDetail {
+				t.Fatalf("typeMismatch() = %q, %v, want %q", detail, ok, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestSchemaAttributeDecodesType(t *testing.T) {
+	var attribute SchemaAttribute
+	if err := json.Unmarshal([]byte(`{"type": ["map", "string"], "optional": true}`), &attribute); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if attribute.Type == nil || !attribute.Type.Equals(cty.Map(cty.String)) {
+		t.Fatalf("Type = %v, want map of string", attribute.Type)
+	}
+
+	encoded, err := json.Marshal(SchemaAttribute{Optional: true})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(encoded) != `{"required":false,"optional":true,"computed":false,"deprecated":false}` {
+		t.Fatalf("untyped attributes should omit the type, got %s", encoded)
+	}
+}
+
+func TestBlockDataValidateReportsTypeMismatches(t *testing.T) {
+	body := parseHCLBody(t, `
+capacity = "two"
+tags     = ["a"]
+name     = var.name
+
+sku {
+  tier = 3
+  size = "large"
+}
+
+dynamic "rule" {
+  for_each = var.rules
+  content {
+    priority = rule.value
+  }
+}
+`)
+
+	parsed := ParseSyntaxBody(body)
+
+	number, str, tags := cty.Number, cty.String, cty.Map(cty.String)
+	schema := &SchemaBlock{
+		Attributes: map[string]*SchemaAttribute{
+			"capacity": {Optional: true, Type: &number},
+			"tags":     {Optional: true, Type: &tags},
+			"name":     {Required: true, Type: &number},
+		},
+		BlockTypes: map[string]*SchemaBlockType{
+			"sku": {Block: &SchemaBlock{Attributes: map[string]*SchemaAttribute{
+				"tier": {Required: true, Type: &str},
+				"size": {Required: true, Type: &number},
+			}}},
+			"rule": {Block: &SchemaBlock{Attributes: map[string]*SchemaAttribute{
+				"priority": {Required: true, Type: &number},
+			}}},
+		},
+	}
+
+	var findings []ValidationFinding
+	parsed.Data.Validate("azurerm_app_service_plan", "root", schema, nil, &findings)
+
+	got := map[string]string{}
+	for _, finding := range findings {
+		if finding.Kind != FindingKindTypeMismatch {
+			t.Errorf("unexpected finding %+v", finding)
+			continue
+		}
+		got[finding.Path+"."+finding.Name] = finding.Detail
+	}
+
+	want := map[string]string{
+		"root.capacity": "expected number, got string",
+		"root.tags":     "expected map of string, got tuple",
+		"root.sku.size": "expected number, got string",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	for key, detail := range want {
+		if got[key] != detail {
+			t.Errorf("finding %s detail = %q, want %q", key, got[key], detail)
+		}
+	}
+}
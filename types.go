@@ -3,6 +3,10 @@ package diffy
 
 import (
 	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type ParseError struct {
@@ -61,8 +65,10 @@ type TerraformSchema struct {
 }
 
 type ProviderSchema struct {
-	ResourceSchemas   map[string]*ResourceSchema `json:"resource_schemas"`
-	DataSourceSchemas map[string]*ResourceSchema `json:"data_source_schemas"`
+	Provider                 *ResourceSchema            `json:"provider,omitempty"`
+	ResourceSchemas          map[string]*ResourceSchema `json:"resource_schemas"`
+	DataSourceSchemas        map[string]*ResourceSchema `json:"data_source_schemas"`
+	EphemeralResourceSchemas map[string]*ResourceSchema `json:"ephemeral_resource_schemas,omitempty"`
 }
 
 type ResourceSchema struct {
@@ -70,15 +76,30 @@ type ResourceSchema struct {
 }
 
 type SchemaBlock struct {
-	Attributes map[string]*SchemaAttribute `json:"attributes"`
-	BlockTypes map[string]*SchemaBlockType `json:"block_types"`
+	Attributes      map[string]*SchemaAttribute `json:"attributes"`
+	BlockTypes      map[string]*SchemaBlockType `json:"block_types"`
+	Description     string                      `json:"description,omitempty"`
+	DescriptionKind string                      `json:"description_kind,omitempty"`
 }
 
 type SchemaAttribute struct {
-	Required   bool `json:"required"`
-	Optional   bool `json:"optional"`
-	Computed   bool `json:"computed"`
-	Deprecated bool `json:"deprecated"`
+	Required        bool              `json:"required"`
+	Optional        bool              `json:"optional"`
+	Computed        bool              `json:"computed"`
+	Deprecated      bool              `json:"deprecated"`
+	Sensitive       bool              `json:"sensitive,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	DescriptionKind string            `json:"description_kind,omitempty"`
+	Type            *cty.Type         `json:"type,omitempty"`
+	NestedType      *SchemaNestedType `json:"nested_type,omitempty"`
+}
+
+// SchemaNestedType describes the object structure of a nested attribute, as used by plugin framework providers.
+type SchemaNestedType struct {
+	Attributes  map[string]*SchemaAttribute `json:"attributes"`
+	NestingMode string                      `json:"nesting_mode"`
+	MinItems    int                         `json:"min_items,omitempty"`
+	MaxItems    int                         `json:"max_items,omitempty"`
 }
 
 type SchemaBlockType struct {
@@ -90,18 +111,29 @@ type SchemaBlockType struct {
 }
 
 type ValidationFinding struct {
+	Kind          FindingKind
 	ResourceType  string
+	Address       string
 	Path          string
 	Name          string
 	Required      bool
 	IsBlock       bool
 	IsDataSource  bool
+	IsEphemeral   bool
+	IsProvider    bool
 	SubmoduleName string
+	Severity      Severity
+	Detail        string
+	Description   string
+	Sensitive     bool
+	Range         hcl.Range
 }
 
 type ProviderConfig struct {
 	Source  string
 	Version string
+	// ConfigurationAliases lists the aliases a module expects its caller to pass, such as "secondary" for azurerm.secondary.
+	ConfigurationAliases []string
 }
 
 type ParsedResource struct {
@@ -116,11 +148,36 @@ type ParsedDataSource struct {
 	Data BlockData
 }
 
+// ParsedEphemeralResource is an ephemeral block, whose values are never persisted to plan or state.
+type ParsedEphemeralResource struct {
+	Type string
+	Name string
+	Data BlockData
+}
+
+// ParsedProvider is a provider configuration block; Alias is empty for the default configuration.
+// ParsedModule holds every block diffy validates, read from the Terraform files of a module.
+type ParsedModule struct {
+	Resources          []ParsedResource
+	DataSources        []ParsedDataSource
+	EphemeralResources []ParsedEphemeralResource
+	Providers          []ParsedProvider
+}
+
+type ParsedProvider struct {
+	Name  string
+	Alias string
+	Data  BlockData
+}
+
 type BlockData struct {
 	Properties    map[string]bool
+	Attributes    map[string]*hclsyntax.Attribute
 	StaticBlocks  map[string][]*ParsedBlock
 	DynamicBlocks map[string]*ParsedBlock
 	IgnoreChanges []string
+	Suppressions  []Suppression
+	Range         hcl.Range
 }
 
 type ParsedBlock struct {
@@ -8,10 +8,19 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
 )
 
 type DefaultSchemaValidator struct {
-	logger Logger
+	logger            Logger
+	exclusions        AttributeExclusions
+	expired           []AttributeSuppression
+	severityOverrides map[string]Severity
+	aliases           map[string]bool
+	entities          []EntityReport
+	skipped           []SkippedEntity
 }
 
 func NewSchemaValidator(logger Logger) *DefaultSchemaValidator {
@@ -20,13 +29,23 @@ func NewSchemaValidator(logger Logger) *DefaultSchemaValidator {
 	}
 }
 
+// Entities returns the resources, data sources and provider configurations validated so far.
+func (validator *DefaultSchemaValidator) Entities() []EntityReport {
+	return validator.entities
+}
+
+// Skipped returns the resources, data sources and provider configurations that could not be matched to a schema.
+func (validator *DefaultSchemaValidator) Skipped() []SkippedEntity {
+	return validator.skipped
+}
+
 func (validator *DefaultSchemaValidator) ValidateResources(
 	resources []ParsedResource,
 	schema TerraformSchema,
 	providers map[string]ProviderConfig,
 	dir, submoduleName string,
 ) []ValidationFinding {
-	return validator.validateEntities(resources, schema, providers, dir, submoduleName, false)
+	return validator.validateEntities(resources, schema, providers, dir, submoduleName, EntityKindResource)
 }
 
 func (validator *DefaultSchemaValidator) ValidateDataSources(
@@ -35,7 +54,28 @@ func (validator *DefaultSchemaValidator) ValidateDataSources(
 	providers map[string]ProviderConfig,
 	dir, submoduleName string,
 ) []ValidationFinding {
-	return validator.validateEntities(dataSources, schema, providers, dir, submoduleName, true)
+	return validator.validateEntities(dataSources, schema, providers, dir, submoduleName, EntityKindDataSource)
+}
+
+// ValidateEphemeralResources validates ephemeral blocks against the provider's ephemeral resource schemas.
+func (validator *DefaultSchemaValidator) ValidateEphemeralResources(
+	ephemeralResources []ParsedEphemeralResource,
+	schema TerraformSchema,
+	providers map[string]ProviderConfig,
+	dir, submoduleName string,
+) []ValidationFinding {
+	return validator.validateEntities(ephemeralResources, schema, providers, dir, submoduleName, EntityKindEphemeralResource)
+}
+
+// ValidateProviders validates provider configuration blocks, aliased ones included, against the provider's own schema.
+func (validator *DefaultSchemaValidator) ValidateProviders(
+	providerBlocks []ParsedProvider,
+	schema TerraformSchema,
+	providers map[string]ProviderConfig,
+	dir, submoduleName string,
+) []ValidationFinding {
+	configured := slices.DeleteFunc(slices.Clone(providerBlocks), proxyProvider)
+	return validator.validateEntities(configured, schema, providers, dir, submoduleName, EntityKindProvider)
 }
 
 func (validator *DefaultSchemaValidator) validateEntities(
@@ -43,9 +83,12 @@ func (validator *DefaultSchemaValidator) validateEntities(
 	schema TerraformSchema,
 	providers map[string]ProviderConfig,
 	dir, submoduleName string,
-	isDataSource bool,
+	kind EntityKind,
 ) []ValidationFinding {
 	var findings []ValidationFinding
+	isDataSource := kind == EntityKindDataSource
+	isEphemeral := kind == EntityKindEphemeralResource
+	isProvider := kind == EntityKindProvider
 
 	var entityList []struct {
 		Type string
@@ -70,70 +113,167 @@ func (validator *DefaultSchemaValidator) validateEntities(
 				Data BlockData
 			}{ds.Type, ds.Name, ds.Data})
 		}
+	case []ParsedEphemeralResource:
+		for _, er := range e {
+			entityList = append(entityList, struct {
+				Type string
+				Name string
+				Data BlockData
+			}{er.Type, er.Name, er.Data})
+		}
+	case []ParsedProvider:
+		for _, p := range e {
+			entityList = append(entityList, struct {
+				Type string
+				Name string
+				Data BlockData
+			}{p.Name, p.Alias, p.Data})
+		}
 	default:
 		return findings
 	}
 
 	for _, entity := range entityList {
-		provName := strings.SplitN(entity.Type, "_", 2)[0]
+		address := entityAddress(submoduleName, entity.Type, entity.Name, kind)
+		skip := func(reason string) {
+			validator.skipped = append(validator.skipped, SkippedEntity{
+				Address:       address,
+				ResourceType:  entity.Type,
+				Name:          entity.Name,
+				IsDataSource:  isDataSource,
+				IsEphemeral:   isEphemeral,
+				IsProvider:    isProvider,
+				SubmoduleName: submoduleName,
+				Reason:        reason,
+			})
+		}
+
+		if reference, undeclared := validator.undeclaredAlias(entity.Data); undeclared {
+			validator.logger.Logf("Provider configuration %s used by %s is not declared in %s", reference, address, dir)
+			skip(SkipReasonUndeclaredAlias)
+			continue
+		}
+
+		provName := validator.providerName(entity.Type, entity.Data, kind, providers)
 		cfg, ok := providers[provName]
 		if !ok {
-			validator.logger.Logf("No provider config for %s type %s in %s",
-				map[bool]string{true: "data source", false: "resource"}[isDataSource],
-				entity.Type, dir)
+			validator.logger.Logf("No provider config for %s type %s in %s", kind, entity.Type, dir)
+			skip(SkipReasonNoProviderConfig)
 			continue
 		}
 
 		pSchema, ok := schema.ProviderSchemas[cfg.Source]
 		if !ok {
 			validator.logger.Logf("No provider schema found for source %s in %s", cfg.Source, dir)
+			skip(SkipReasonNoProviderSchema)
 			continue
 		}
 
-		var resSchema *ResourceSchema
-		var schemaExists bool
-		if isDataSource {
-			resSchema, schemaExists = pSchema.DataSourceSchemas[entity.Type]
-		} else {
-			resSchema, schemaExists = pSchema.ResourceSchemas[entity.Type]
-		}
-
+		resSchema, schemaExists := pSchema.schema(kind, entity.Type)
 		if !schemaExists {
-			entityType := map[bool]string{true: "data source", false: "resource"}[isDataSource]
 			validator.logger.Logf("No %s schema found for %s in provider %s (dir=%s)",
-				entityType, entity.Type, cfg.Source, dir)
+				kind, entity.Type, cfg.Source, dir)
+			skip(SkipReasonNoSchema)
 			continue
 		}
 
+		entityFindings := 0
+
 		var localFindings []ValidationFinding
-		entity.Data.Validate(entity.Type, "root", resSchema.Block, entity.Data.IgnoreChanges, &localFindings)
+		exclusions := validator.exclusions.Scope(submoduleName, kind)
+		entity.Data.ValidateWithExclusions(entity.Type, "root", resSchema.Block, entity.Data.IgnoreChanges, exclusions, &localFindings)
+
+		localFindings = applySuppressions(entity.Type, &entity.Data, localFindings)
 
 		for i := range localFindings {
-			shouldExclude := false
-			for _, ignored := range entity.Data.IgnoreChanges {
-				if strings.EqualFold(ignored, localFindings[i].Name) {
-					shouldExclude = true
-					break
-				}
-			}
+			shouldExclude := localFindings[i].Kind == FindingKindMissing &&
+				slices.ContainsFunc(entity.Data.IgnoreChanges, func(ignored string) bool {
+					return strings.EqualFold(ignored, localFindings[i].Name)
+				})
 
 			if !shouldExclude {
+				localFindings[i].Address = address
 				localFindings[i].SubmoduleName = submoduleName
 				localFindings[i].IsDataSource = isDataSource
+				localFindings[i].IsEphemeral = isEphemeral
+				localFindings[i].IsProvider = isProvider
+				localFindings[i].overrideSeverity(validator.severityOverrides)
 				findings = append(findings, localFindings[i])
+				entityFindings++
+
+				for _, suppression := range validator.expiredSuppressionsFor(localFindings[i]) {
+					expired := expiredFinding(localFindings[i], suppression.SuppressionMetadata)
+					expired.overrideSeverity(validator.severityOverrides)
+					findings = append(findings, expired)
+					entityFindings++
+				}
 			}
 		}
+
+		validator.entities = append(validator.entities, EntityReport{
+			Address:        address,
+			ResourceType:   entity.Type,
+			Name:           entity.Name,
+			IsDataSource:   isDataSource,
+			IsEphemeral:    isEphemeral,
+			IsProvider:     isProvider,
+			ProviderSource: cfg.Source,
+			Range:          entity.Data.Range,
+			Findings:       entityFindings,
+			Schema:         resSchema.Block,
+			Data:           entity.Data,
+			Exclusions:     exclusions,
+		})
 	}
 
 	return findings
 }
 
+// expiredSuppressionsFor returns the lapsed suppressions that would otherwise have hidden the finding.
+func (validator *DefaultSchemaValidator) expiredSuppressionsFor(finding ValidationFinding) []AttributeSuppression {
+	if finding.isSuppressionFinding() {
+		return nil
+	}
+
+	var matched []AttributeSuppression
+	for _, suppression := range validator.expired {
+		exclusions := NewAttributeExclusions(suppression.Pattern).Scope(finding.SubmoduleName, finding.EntityKind())
+		if exclusions.ExcludesFinding(finding) {
+			matched = append(matched, suppression)
+		}
+	}
+	return matched
+}
+
 func ValidateTerraformSchema(logger Logger, dir, submoduleName string, parser HCLParser, runner TerraformRunner) ([]ValidationFinding, error) {
 	return ValidateTerraformSchemaWithOptions(logger, dir, submoduleName, parser, runner, nil, nil)
 }
 
 func ValidateTerraformSchemaWithOptions(logger Logger, dir, submoduleName string, parser HCLParser, runner TerraformRunner, excludedResources, excludedDataSources []string) ([]ValidationFinding, error) {
+	opts := &SchemaValidatorOptions{
+		Logger:              logger,
+		ExcludedResources:   excludedResources,
+		ExcludedDataSources: excludedDataSources,
+	}
+
+	module, err := validateModule(opts, parser, runner, dir, submoduleName)
+	if err != nil {
+		return nil, err
+	}
+	return module.Findings, nil
+}
+
+func validateModule(opts *SchemaValidatorOptions, parser HCLParser, runner TerraformRunner, dir, submoduleName string) (*ModuleReport, error) {
 	ctx := context.Background()
+	started := time.Now()
+
+	if submoduleName != "" {
+		scoped, err := moduleOptions(opts, dir)
+		if err != nil {
+			return nil, err
+		}
+		opts = scoped
+	}
 
 	terraformFiles, err := walkTerraformFiles(dir)
 	if err != nil {
@@ -158,20 +298,81 @@ func ValidateTerraformSchemaWithOptions(logger Logger, dir, submoduleName string
 		return nil, err
 	}
 
-	resources, dataSources, err := parser.ParseTerraformFiles(ctx, terraformFiles)
+	parsed, err := parseModuleFiles(ctx, parser, terraformFiles)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Terraform resources in %s: %w", dir, err)
+		return nil, fmt.Errorf("failed to parse Terraform files in %s: %w", dir, err)
 	}
+	resources, dataSources := parsed.Resources, parsed.DataSources
+	ephemeralResources, providerBlocks := parsed.EphemeralResources, parsed.Providers
 
-	resources = filterResources(resources, excludedResources)
-	dataSources = filterDataSources(dataSources, excludedDataSources)
+	module := &ModuleReport{
+		Name: submoduleName,
+		Path: dir,
+	}
 
-	validator := NewSchemaValidator(logger)
-	var findings []ValidationFinding
-	findings = append(findings, validator.ValidateResources(resources, *tfSchema, providers, dir, submoduleName)...)
-	findings = append(findings, validator.ValidateDataSources(dataSources, *tfSchema, providers, dir, submoduleName)...)
+	for _, resource := range resources {
+		if slices.Contains(opts.ExcludedResources, resource.Type) {
+			module.Skipped = append(module.Skipped, excludedEntity(submoduleName, resource.Type, resource.Name, EntityKindResource))
+		}
+	}
+	for _, dataSource := range dataSources {
+		if slices.Contains(opts.ExcludedDataSources, dataSource.Type) {
+			module.Skipped = append(module.Skipped, excludedEntity(submoduleName, dataSource.Type, dataSource.Name, EntityKindDataSource))
+		}
+	}
+	for _, ephemeral := range ephemeralResources {
+		if slices.Contains(opts.ExcludedEphemeral, ephemeral.Type) {
+			module.Skipped = append(module.Skipped, excludedEntity(submoduleName, ephemeral.Type, ephemeral.Name, EntityKindEphemeralResource))
+		}
+	}
+
+	resources = filterResources(resources, opts.ExcludedResources)
+	dataSources = filterDataSources(dataSources, opts.ExcludedDataSources)
+	ephemeralResources = filterEphemeralResources(ephemeralResources, opts.ExcludedEphemeral)
+
+	validator := NewSchemaValidator(opts.Logger)
+	patterns := append(slices.Clone(opts.ExcludedAttributes), ignoredAttributePatterns(opts.IgnoredAttributes)...)
+	today := now()
+	for _, suppression := range opts.Suppressions {
+		if suppression.Expired(today) {
+			validator.expired = append(validator.expired, suppression)
+			continue
+		}
+		patterns = append(patterns, suppression.Pattern)
+	}
+	validator.exclusions = NewAttributeExclusions(patterns...)
+	validator.severityOverrides = opts.SeverityOverrides
+	validator.aliases = providerAliases(providers, providerBlocks)
+	module.Findings = append(module.Findings, validator.ValidateResources(resources, *tfSchema, providers, dir, submoduleName)...)
+	module.Findings = append(module.Findings, validator.ValidateDataSources(dataSources, *tfSchema, providers, dir, submoduleName)...)
+	module.Findings = append(module.Findings, validator.ValidateEphemeralResources(ephemeralResources, *tfSchema, providers, dir, submoduleName)...)
+	module.Findings = append(module.Findings, validator.ValidateProviders(providerBlocks, *tfSchema, providers, dir, submoduleName)...)
+	module.Entities = validator.Entities()
+	module.Skipped = append(module.Skipped, validator.Skipped()...)
+
+	module.Status = moduleStatus(module.Findings)
+	module.Duration = time.Since(started)
+
+	return module, nil
+}
+
+func moduleStatus(findings []ValidationFinding) string {
+	if slices.ContainsFunc(findings, ValidationFinding.fails) {
+		return ModuleStatusFailed
+	}
+	return ModuleStatusPassed
+}
 
-	return findings, nil
+func excludedEntity(submoduleName, resourceType, name string, kind EntityKind) SkippedEntity {
+	return SkippedEntity{
+		Address:       entityAddress(submoduleName, resourceType, name, kind),
+		ResourceType:  resourceType,
+		Name:          name,
+		IsDataSource:  kind == EntityKindDataSource,
+		IsEphemeral:   kind == EntityKindEphemeralResource,
+		SubmoduleName: submoduleName,
+		Reason:        SkipReasonExcluded,
+	}
 }
 
 func filterResources(resources []ParsedResource, excluded []string) []ParsedResource {
@@ -202,6 +403,20 @@ func filterDataSources(dataSources []ParsedDataSource, excluded []string) []Pars
 	return filtered
 }
 
+func filterEphemeralResources(ephemeralResources []ParsedEphemeralResource, excluded []string) []ParsedEphemeralResource {
+	if len(excluded) == 0 {
+		return ephemeralResources
+	}
+
+	var filtered []ParsedEphemeralResource
+	for _, ephemeral := range ephemeralResources {
+		if !slices.Contains(excluded, ephemeral.Type) {
+			filtered = append(filtered, ephemeral)
+		}
+	}
+	return filtered
+}
+
 func walkTerraformFiles(dir string) ([]string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -227,8 +442,10 @@ func DeduplicateFindings(findings []ValidationFinding) []ValidationFinding {
 	result := make([]ValidationFinding, 0, len(findings))
 
 	for _, finding := range findings {
-		key := fmt.Sprintf("%s|%s|%s|%v|%v|%s",
+		key := fmt.Sprintf("%s|%s|%s|%s|%s|%v|%v|%s",
+			finding.Kind,
 			finding.ResourceType,
+			finding.Address,
 			finding.Path,
 			finding.Name,
 			finding.IsBlock,
@@ -252,26 +469,123 @@ func FormatFinding(finding ValidationFinding) string {
 		cleanPath = "root"
 	}
 
-	requiredOptional := "optional"
-	if finding.Required {
-		requiredOptional = "required"
+	entityType := finding.EntityKind()
+
+	place := cleanPath
+	if finding.SubmoduleName != "" {
+		place = place + " in submodule " + finding.SubmoduleName
+	}
+
+	message := fmt.Sprintf("%s: %s %s in %s (%s)",
+		findingSubject(finding), findingSummary(finding), finding.Name, place, entityType)
+
+	if location := FormatLocation(finding.Range); location != "" {
+		message += " at " + location
+	}
+
+	if finding.Detail != "" {
+		message += " - " + finding.Detail
 	}
 
+	return message
+}
+
+// ResourceAddress builds the Terraform address of a resource or data source, prefixed with the submodule path.
+func ResourceAddress(submoduleName, resourceType, name string, isDataSource bool) string {
+	return entityAddress(submoduleName, resourceType, name, entityKindOf(isDataSource, false, false))
+}
+
+// findingSummary describes what is wrong with the attribute or block a finding names.
+func findingSummary(finding ValidationFinding) string {
 	blockOrProp := "property"
 	if finding.IsBlock {
 		blockOrProp = "block"
 	}
 
-	entityType := "resource"
-	if finding.IsDataSource {
-		entityType = "data source"
+	switch finding.Kind {
+	case FindingKindUnusedSuppression:
+		return "unused suppression for " + blockOrProp
+	case FindingKindExpiredSuppression:
+		return "expired suppression for " + blockOrProp
+	case FindingKindDeprecated:
+		return "deprecated " + blockOrProp
+	case FindingKindUnknown:
+		return "unknown " + blockOrProp
+	case FindingKindComputed:
+		return "computed-only property"
+	case FindingKindCardinality:
+		return "invalid number of block"
+	case FindingKindUnknownCardinality:
+		return "unverified number of block"
+	case FindingKindTypeMismatch:
+		return "invalid value for property"
+	default:
+		requiredOptional := "optional"
+		if finding.Required {
+			requiredOptional = "required"
+		}
+		return "missing " + requiredOptional + " " + blockOrProp
 	}
+}
 
-	place := cleanPath
-	if finding.SubmoduleName != "" {
-		place = place + " in submodule " + finding.SubmoduleName
+func findingSubject(finding ValidationFinding) string {
+	if finding.Address != "" {
+		return finding.Address
+	}
+	return finding.ResourceType
+}
+
+// FormatLocation renders a source range as file:line:column, relative to the working directory when possible.
+func FormatLocation(r hcl.Range) string {
+	if r.Filename == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%d", displayPath(r.Filename), r.Start.Line, r.Start.Column)
+}
+
+func displayPath(filename string) string {
+	if !filepath.IsAbs(filename) {
+		return filepath.ToSlash(filename)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(filename)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// FindResourceSchema looks up a resource or data source type across all provider schemas.
+func FindResourceSchema(schema *TerraformSchema, resourceType string, isDataSource bool) (string, *ResourceSchema, bool) {
+	return FindEntitySchema(schema, resourceType, entityKindOf(isDataSource, false, false))
+}
+
+// FindEntitySchema looks up the schema of a resource, data source, ephemeral resource or provider type across all
+// provider schemas; a provider type is its name in the source address, such as azurerm.
+func FindEntitySchema(schema *TerraformSchema, entityType string, kind EntityKind) (string, *ResourceSchema, bool) {
+	if schema == nil {
+		return "", nil, false
+	}
+
+	sources := slices.Sorted(maps.Keys(schema.ProviderSchemas))
+	for _, source := range sources {
+		pSchema := schema.ProviderSchemas[source]
+		if pSchema == nil {
+			continue
+		}
+		if kind == EntityKindProvider && !strings.HasSuffix(source, "/"+entityType) {
+			continue
+		}
+
+		if resSchema, ok := pSchema.schema(kind, entityType); ok {
+			return source, resSchema, true
+		}
 	}
 
-	return fmt.Sprintf("%s: missing %s %s %s in %s (%s)",
-		finding.ResourceType, requiredOptional, blockOrProp, finding.Name, place, entityType)
+	return "", nil, false
 }
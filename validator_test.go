@@ -2,9 +2,15 @@ package diffy
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
 )
 
 func TestDeduplicateFindings(t *testing.T) {
@@ -73,6 +79,24 @@ func TestDeduplicateFindings(t *testing.T) {
 			},
 			wantCount: 2,
 		},
+		{
+			name: "same type and attribute on different resources",
+			findings: []ValidationFinding{
+				{
+					ResourceType: "azurerm_storage_account",
+					Address:      "azurerm_storage_account.logs",
+					Name:         "min_tls_version",
+					Path:         "root",
+				},
+				{
+					ResourceType: "azurerm_storage_account",
+					Address:      "azurerm_storage_account.data",
+					Name:         "min_tls_version",
+					Path:         "root",
+				},
+			},
+			wantCount: 2,
+		},
 		{
 			name:      "empty findings",
 			findings:  []ValidationFinding{},
@@ -157,6 +181,148 @@ func TestFormatFinding(t *testing.T) {
 	}
 }
 
+func TestResourceAddress(t *testing.T) {
+	tests := []struct {
+		name          string
+		submoduleName string
+		resourceType  string
+		resourceName  string
+		isDataSource  bool
+		want          string
+	}{
+		{name: "root resource", resourceType: "azurerm_storage_account", resourceName: "sa", want: "azurerm_storage_account.sa"},
+		{name: "root data source", resourceType: "azurerm_client_config", resourceName: "current", isDataSource: true, want: "data.azurerm_client_config.current"},
+		{name: "submodule resource", submoduleName: "network", resourceType: "azurerm_subnet", resourceName: "this", want: "module.network.azurerm_subnet.this"},
+		{name: "submodule data source", submoduleName: "network", resourceType: "azurerm_subnet", resourceName: "this", isDataSource: true, want: "module.network.data.azurerm_subnet.this"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResourceAddress(tt.submoduleName, tt.resourceType, tt.resourceName, tt.isDataSource); got != tt.want {
+				t.Errorf("ResourceAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEntitiesSetsAddress(t *testing.T) {
+	validator := NewSchemaValidator(&SimpleLogger{})
+	source := "registry.terraform.io/hashicorp/azurerm"
+	schema := TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				ResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_storage_account": {
+						Block: &SchemaBlock{
+							Attributes: map[string]*SchemaAttribute{
+								"min_tls_version": {Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	findings := validator.validateEntities(
+		[]ParsedResource{
+			{Type: "azurerm_storage_account", Name: "logs", Data: NewBlockData()},
+			{Type: "azurerm_storage_account", Name: "data", Data: NewBlockData()},
+		},
+		schema,
+		map[string]ProviderConfig{"azurerm": {Source: source}},
+		".",
+		"storage",
+		EntityKindResource,
+	)
+
+	if len(DeduplicateFindings(findings)) != 2 {
+		t.Fatalf("expected one finding per resource, got %+v", findings)
+	}
+
+	addresses := map[string]bool{}
+	for _, f := range findings {
+		addresses[f.Address] = true
+		if !strings.HasPrefix(FormatFinding(f), f.Address+": ") {
+			t.Errorf("FormatFinding() should start with the address, got %q", FormatFinding(f))
+		}
+	}
+
+	if !addresses["module.storage.azurerm_storage_account.logs"] || !addresses["module.storage.azurerm_storage_account.data"] {
+		t.Fatalf("unexpected addresses %v", addresses)
+	}
+}
+
+func TestValidateEntitiesReportsDeprecatedDespiteIgnoreChanges(t *testing.T) {
+	validator := NewSchemaValidator(&SimpleLogger{})
+	source := "registry.terraform.io/hashicorp/azurerm"
+	schema := TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				ResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_storage_account": {
+						Block: &SchemaBlock{
+							Attributes: map[string]*SchemaAttribute{
+								"allow_blob_public_access": {Optional: true, Deprecated: true},
+								"min_tls_version":          {Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	data := NewBlockData()
+	data.Properties["allow_blob_public_access"] = true
+	data.IgnoreChanges = []string{"allow_blob_public_access", "min_tls_version"}
+
+	findings := validator.validateEntities(
+		[]ParsedResource{{Type: "azurerm_storage_account", Name: "logs", Data: data}},
+		schema,
+		map[string]ProviderConfig{"azurerm": {Source: source}},
+		".",
+		"",
+		EntityKindResource,
+	)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected only the deprecated finding, got %+v", findings)
+	}
+	if got := FormatFinding(findings[0]); got != "azurerm_storage_account.logs: deprecated property allow_blob_public_access in root (resource)" {
+		t.Fatalf("FormatFinding() = %q", got)
+	}
+}
+
+func TestFormatFindingIncludesLocation(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+
+	finding := ValidationFinding{
+		ResourceType: "azurerm_virtual_network",
+		Path:         "root",
+		Name:         "location",
+		Required:     true,
+		Range: hcl.Range{
+			Filename: filepath.Join(wd, "modules", "network", "main.tf"),
+			Start:    hcl.Pos{Line: 12, Column: 1},
+			End:      hcl.Pos{Line: 20, Column: 2},
+		},
+	}
+
+	got := FormatFinding(finding)
+	if !strings.HasSuffix(got, " at modules/network/main.tf:12:1") {
+		t.Fatalf("FormatFinding() = %q, should end with the relative location", got)
+	}
+
+	finding.Range = hcl.Range{}
+	if got := FormatFinding(finding); strings.Contains(got, " at ") {
+		t.Fatalf("FormatFinding() without a range should not include a location, got %q", got)
+	}
+}
+
 func TestFilterResources(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -270,7 +436,7 @@ func TestValidateEntitiesMissingProviderOrSchema(t *testing.T) {
 		map[string]ProviderConfig{}, // missing provider config, should log and skip
 		".",
 		"",
-		false,
+		EntityKindResource,
 	)
 
 	if len(findings) != 0 {
@@ -291,7 +457,7 @@ func TestValidateEntitiesMissingProviderOrSchema(t *testing.T) {
 		map[string]ProviderConfig{"azurerm": {Source: "registry.terraform.io/hashicorp/azurerm"}},
 		".",
 		"",
-		false,
+		EntityKindResource,
 	)
 
 	if len(findings) != 0 {
@@ -337,7 +503,7 @@ func TestValidateBlocksMultipleStaticAndDynamic(t *testing.T) {
 	}
 
 	var findings []ValidationFinding
-	bd.validateBlocks("azurerm_virtual_network", "root", schema, nil, &findings)
+	bd.validateBlocks("azurerm_virtual_network", "root", schema, nil, AttributeExclusions{}, &findings)
 
 	if len(findings) != 3 {
 		t.Fatalf("expected findings for each static and dynamic child, got %d", len(findings))
@@ -535,10 +701,11 @@ func TestValidateProjectWithSubmodules(t *testing.T) {
 		TerraformRunner: runner,
 	}
 
-	findings, err := validateProject(opts)
+	report, err := validateProject(opts)
 	if err != nil {
 		t.Fatalf("validateProject returned error: %v", err)
 	}
+	findings := report.Findings
 
 	if len(findings) != 2 {
 		t.Fatalf("expected findings for root and submodule, got %d", len(findings))
@@ -559,6 +726,256 @@ func TestValidateProjectWithSubmodules(t *testing.T) {
 	if !runner.initCalled(root) || !runner.initCalled(modulesDir) {
 		t.Fatalf("runner.Init should be invoked for root and submodule, got %v", runner.inited)
 	}
+
+	if len(report.Modules) != 2 || report.Modules[0].DisplayName() != "root" || report.Modules[1].Name != "network" {
+		t.Fatalf("report should list root first and then submodules, got %+v", report.Modules)
+	}
+	for _, module := range report.Modules {
+		if module.Status != ModuleStatusFailed || len(module.Entities) != 1 {
+			t.Fatalf("unexpected module report: %+v", module)
+		}
+	}
+}
+
+func TestValidateModuleValidatesEphemeralResources(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), `ephemeral "azurerm_key_vault_secret" "password" {
+  key_vault_id = "kv"
+}
+
+ephemeral "random_password" "admin" {}
+`)
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	runner := &stubRunner{schema: &TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				EphemeralResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_key_vault_secret": {Block: &SchemaBlock{
+						Attributes: map[string]*SchemaAttribute{
+							"name":         {Required: true},
+							"key_vault_id": {Required: true},
+							"value":        {Computed: true, Sensitive: true},
+						},
+					}},
+				},
+			},
+		},
+	}}
+	parser := &configStubParser{DefaultHCLParser: NewHCLParser(), source: source}
+
+	module, err := validateModule(&SchemaValidatorOptions{Logger: &SimpleLogger{}}, parser, runner, dir, "")
+	if err != nil {
+		t.Fatalf("validateModule() error = %v", err)
+	}
+
+	if len(module.Findings) != 1 {
+		t.Fatalf("expected one finding, got %+v", module.Findings)
+	}
+	finding := module.Findings[0]
+	if finding.Name != "name" || !finding.Required || !finding.IsEphemeral {
+		t.Errorf("unexpected finding: %+v", finding)
+	}
+	if finding.Address != "ephemeral.azurerm_key_vault_secret.password" {
+		t.Errorf("Address = %q, want ephemeral.azurerm_key_vault_secret.password", finding.Address)
+	}
+	if got := FormatFinding(finding); !strings.Contains(got, "(ephemeral resource)") {
+		t.Errorf("FormatFinding() = %q, want it to name the ephemeral resource kind", got)
+	}
+
+	if len(module.Entities) != 1 || module.Entities[0].EntityKind() != EntityKindEphemeralResource {
+		t.Errorf("Entities = %+v, want the ephemeral resource", module.Entities)
+	}
+	if len(module.Skipped) != 1 || module.Skipped[0].Address != "ephemeral.random_password.admin" || module.Skipped[0].Reason != SkipReasonNoProviderConfig {
+		t.Errorf("Skipped = %+v, want random_password without provider config", module.Skipped)
+	}
+
+	excluded, err := validateModule(&SchemaValidatorOptions{
+		Logger:            &SimpleLogger{},
+		ExcludedEphemeral: []string{"azurerm_key_vault_secret"},
+	}, parser, runner, dir, "")
+	if err != nil {
+		t.Fatalf("validateModule() error = %v", err)
+	}
+	if len(excluded.Findings) != 0 {
+		t.Errorf("expected excluded ephemeral resource to produce no findings, got %+v", excluded.Findings)
+	}
+	if len(excluded.Skipped) == 0 || excluded.Skipped[0].Reason != SkipReasonExcluded || !excluded.Skipped[0].IsEphemeral {
+		t.Errorf("Skipped = %+v, want the excluded ephemeral resource first", excluded.Skipped)
+	}
+}
+
+func TestValidateModuleValidatesProviderBlocks(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "providers.tf"), `provider "azurerm" {
+  features {}
+}
+
+provider "azurerm" {
+  alias = "secondary"
+  subscription_id = "00000000-0000-0000-0000-000000000000"
+}
+
+provider "random" {}
+`)
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	var schema TerraformSchema
+	if err := json.Unmarshal([]byte(`{"provider_schemas": {"`+source+`": {
+  "provider": {"block": {
+    "attributes": {"subscription_id": {"type": "string", "optional": true}},
+    "block_types": {"features": {"nesting": "list", "min_items": 1, "max_items": 1, "block": {}}}
+  }},
+  "resource_schemas": {}
+}}}`), &schema); err != nil {
+		t.Fatalf("failed to decode schema: %v", err)
+	}
+	runner := &stubRunner{schema: &schema}
+	parser := &configStubParser{DefaultHCLParser: NewHCLParser(), source: source}
+
+	module, err := validateModule(&SchemaValidatorOptions{Logger: &SimpleLogger{}}, parser, runner, dir, "")
+	if err != nil {
+		t.Fatalf("validateModule() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range module.Findings {
+		if !f.IsProvider {
+			t.Errorf("finding should be marked as a provider finding: %+v", f)
+		}
+		got = append(got, f.Address+" "+f.RuleID()+" "+f.Name)
+	}
+	want := []string{
+		"provider.azurerm missing-optional-attribute subscription_id",
+		"provider.azurerm.secondary missing-required-block features",
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(module.Findings) > 0 && !strings.Contains(FormatFinding(module.Findings[0]), "(provider)") {
+		t.Errorf("FormatFinding() = %q, want it to name the provider kind", FormatFinding(module.Findings[0]))
+	}
+	if len(module.Entities) != 2 || module.Entities[0].EntityKind() != EntityKindProvider {
+		t.Errorf("Entities = %+v, want both azurerm provider configurations", module.Entities)
+	}
+	if len(module.Skipped) != 1 || module.Skipped[0].Address != "provider.random" || module.Skipped[0].Reason != SkipReasonNoProviderConfig {
+		t.Errorf("Skipped = %+v, want the random provider without provider config", module.Skipped)
+	}
+}
+
+func TestValidateModuleSkipsProxyProviderBlocks(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "providers.tf"), `provider "azurerm" {
+  alias = "hub"
+}
+
+resource "azurerm_key_vault" "kv" {
+  provider = azurerm.hub
+}
+`)
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	var schema TerraformSchema
+	if err := json.Unmarshal([]byte(`{"provider_schemas": {"`+source+`": {
+  "provider": {"block": {
+    "block_types": {"features": {"nesting": "list", "min_items": 1, "max_items": 1, "block": {}}}
+  }},
+  "resource_schemas": {"azurerm_key_vault": {"block": {
+    "attributes": {"sku_name": {"type": "string", "required": true}}
+  }}}
+}}}`), &schema); err != nil {
+		t.Fatalf("failed to decode schema: %v", err)
+	}
+	runner := &stubRunner{schema: &schema}
+	parser := &configStubParser{DefaultHCLParser: NewHCLParser(), source: source}
+
+	module, err := validateModule(&SchemaValidatorOptions{Logger: &SimpleLogger{}}, parser, runner, dir, "")
+	if err != nil {
+		t.Fatalf("validateModule() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range module.Findings {
+		got = append(got, f.Address+" "+f.RuleID()+" "+f.Name)
+	}
+	if diff := cmp.Diff([]string{"azurerm_key_vault.kv missing-required-attribute sku_name"}, got); diff != "" {
+		t.Errorf("a proxy provider block should not be validated (-want +got):\n%s", diff)
+	}
+	if len(module.Entities) != 1 || module.Entities[0].EntityKind() != EntityKindResource || len(module.Skipped) != 0 {
+		t.Errorf("Entities = %+v, Skipped = %+v, want only the key vault", module.Entities, module.Skipped)
+	}
+}
+
+func TestValidateModuleHonorsProviderMetaArgument(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), `terraform {
+  required_providers {
+    az = {
+      source                = "hashicorp/azurerm"
+      configuration_aliases = [az.secondary]
+    }
+  }
+}
+
+resource "azurerm_key_vault" "primary" {
+  provider = az
+}
+
+resource "azurerm_key_vault" "secondary" {
+  provider = az.secondary
+}
+
+resource "azurerm_key_vault" "tertiary" {
+  provider = az.tertiary
+}
+
+resource "azurerm_key_vault" "implicit" {}
+`)
+
+	source := "registry.terraform.io/hashicorp/azurerm"
+	runner := &stubRunner{schema: &TerraformSchema{
+		ProviderSchemas: map[string]*ProviderSchema{
+			source: {
+				ResourceSchemas: map[string]*ResourceSchema{
+					"azurerm_key_vault": {Block: &SchemaBlock{
+						Attributes: map[string]*SchemaAttribute{
+							"sku_name": {Required: true},
+						},
+					}},
+				},
+			},
+		},
+	}}
+
+	module, err := validateModule(&SchemaValidatorOptions{Logger: &SimpleLogger{}}, NewHCLParser(), runner, dir, "")
+	if err != nil {
+		t.Fatalf("validateModule() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range module.Findings {
+		got = append(got, f.Address+" "+f.Name)
+	}
+	want := []string{
+		"azurerm_key_vault.primary sku_name",
+		"azurerm_key_vault.secondary sku_name",
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	var skipped []string
+	for _, entity := range module.Skipped {
+		skipped = append(skipped, entity.Address+" "+entity.Reason)
+	}
+	wantSkipped := []string{
+		"azurerm_key_vault.implicit " + SkipReasonNoProviderConfig,
+		"azurerm_key_vault.tertiary " + SkipReasonUndeclaredAlias,
+	}
+	if diff := cmp.Diff(wantSkipped, skipped, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("skipped mismatch (-want +got):\n%s", diff)
+	}
 }
 
 type stubParser struct {
@@ -634,3 +1051,41 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFindEntitySchema(t *testing.T) {
+	password := &ResourceSchema{Block: &SchemaBlock{}}
+	provider := &ResourceSchema{Block: &SchemaBlock{}}
+	schema := &TerraformSchema{ProviderSchemas: map[string]*ProviderSchema{
+		"registry.terraform.io/hashicorp/azurerm": {
+			Provider:        &ResourceSchema{Block: &SchemaBlock{}},
+			ResourceSchemas: map[string]*ResourceSchema{"azurerm_resource_group": {Block: &SchemaBlock{}}},
+		},
+		"registry.terraform.io/hashicorp/random": {
+			Provider:                 provider,
+			EphemeralResourceSchemas: map[string]*ResourceSchema{"random_password": password},
+		},
+	}}
+
+	tests := []struct {
+		name       string
+		entityType string
+		kind       EntityKind
+		want       *ResourceSchema
+		wantSource string
+	}{
+		{name: "ephemeral resource", entityType: "random_password", kind: EntityKindEphemeralResource, want: password, wantSource: "registry.terraform.io/hashicorp/random"},
+		{name: "ephemeral resource is not a resource", entityType: "random_password", kind: EntityKindResource},
+		{name: "resource is not an ephemeral resource", entityType: "azurerm_resource_group", kind: EntityKindEphemeralResource},
+		{name: "provider by type", entityType: "random", kind: EntityKindProvider, want: provider, wantSource: "registry.terraform.io/hashicorp/random"},
+		{name: "unknown provider", entityType: "aws", kind: EntityKindProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, got, ok := FindEntitySchema(schema, tt.entityType, tt.kind)
+			if got != tt.want || source != tt.wantSource || ok != (tt.want != nil) {
+				t.Errorf("FindEntitySchema() = %q, %v, %v, want %q, %v", source, got, ok, tt.wantSource, tt.want)
+			}
+		})
+	}
+}